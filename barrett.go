@@ -0,0 +1,97 @@
+// MIT License
+
+// Copyright (c) 2018 Akhil Indurti
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package extprec
+
+// A Barrett64 reduces 128-bit values modulo a fixed 64-bit modulus m
+// by Barrett reduction, which replaces the division by m with
+// multiplications by a precomputed approximation of 2^128/m.
+// Unlike Montgomery64, it works for any nonzero modulus, and its
+// inputs and results are ordinary values rather than a special form.
+type Barrett64 struct {
+	m  uint64  // modulus
+	mu Uint128 // floor((2^128-1)/m)
+}
+
+// NewBarrett64 returns a Barrett64 for the modulus m.
+// NewBarrett64 panics with ErrDivideByZero if m is zero.
+func NewBarrett64(m uint64) Barrett64 {
+	// floor((2^128-1)/m) equals floor(2^128/m) unless m is a power
+	// of two, where it is one less; unlike floor(2^128/m), it always
+	// fits in 128 bits, and Reduce corrects for the difference.
+	hi, lo := Quo64(1<<64-1, 1<<64-1, m)
+	return Barrett64{m: m, mu: Uint128{hi, lo}}
+}
+
+// Modulus returns the modulus m.
+func (b Barrett64) Modulus() uint64 {
+	return b.m
+}
+
+// Reduce returns (hi || lo) mod m, where hi and lo hold the most
+// significant and least significant 64 bits of the value.
+// Reduce panics with ErrDivideByZero if b is the zero Barrett64.
+func (b Barrett64) Reduce(hi, lo uint64) uint64 {
+	// See Barrett, "Implementing the Rivest Shamir and Adleman public key
+	// encryption algorithm on a standard digital signal processor",
+	// CRYPTO '86, and "Handbook of Applied Cryptography", Algorithm 14.42.
+	//
+	// Estimate the quotient as the high 128 bits of x*mu. Since mu
+	// underestimates 2^128/m by less than 2, the estimate is at most
+	// 2 less than the true quotient, and r = x - q*m is less than 3m.
+	if b.m == 0 {
+		panic(ErrDivideByZero)
+	}
+	x := Uint128{hi, lo}
+	q := mulHi128(x, b.mu)
+	r := x.Sub(q.Mul(Uint128{0, b.m}))
+	for r.Hi != 0 || r.Lo >= b.m {
+		r = r.Sub(Uint128{0, b.m})
+	}
+	return r.Lo
+}
+
+// MulMod returns x*y mod m.
+func (b Barrett64) MulMod(x, y uint64) uint64 {
+	return b.Reduce(Mul64(x, y))
+}
+
+// mulHi128 returns the most significant 128 bits
+// of the 256-bit product of x and y.
+func mulHi128(x, y Uint128) Uint128 {
+	// Schoolbook multiplication with 64-bit digits,
+	// keeping only the carries out of the two low words.
+	h00, _ := Mul64(x.Lo, y.Lo)
+	h01, l01 := Mul64(x.Lo, y.Hi)
+	h10, l10 := Mul64(x.Hi, y.Lo)
+	h11, l11 := Mul64(x.Hi, y.Hi)
+
+	// Word 1 of the product only contributes its carries.
+	w1, c1 := Add64(h00, l01, 0)
+	_, c2 := Add64(w1, l10, 0)
+
+	// Word 2, and the carries into word 3.
+	w2, c3 := Add64(h01, h10, 0)
+	w2, c4 := Add64(w2, l11, 0)
+	w2, c5 := Add64(w2, c1+c2, 0)
+	return Uint128{h11 + c3 + c4 + c5, w2}
+}
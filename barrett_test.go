@@ -0,0 +1,76 @@
+// MIT License
+
+// Copyright (c) 2018 Akhil Indurti
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package extprec
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestBarrett64(t *testing.T) {
+	r := rand.New(rand.NewSource(18))
+	his := []uint64{0, 1, 0xFF, 1<<63 - 1, 1 << 63, 1<<64 - 1}
+	for _, m := range moduli64(r, 200) {
+		b := NewBarrett64(m)
+		if b.Modulus() != m {
+			t.Errorf("NewBarrett64(%d).Modulus() == %d", m, b.Modulus())
+		}
+		for _, hi := range append(his, r.Uint64(), r.Uint64()) {
+			for _, lo := range append(his, r.Uint64(), r.Uint64()) {
+				_, want := Div64(hi%m, lo, m)
+				if got := b.Reduce(hi, lo); got != want {
+					t.Errorf("NewBarrett64(%d).Reduce(0x%X, 0x%X) == %d; want %d", m, hi, lo, got, want)
+				}
+			}
+		}
+		xs := residues(r, m, 10)
+		for _, x := range xs {
+			for _, y := range xs {
+				hi, lo := Mul64(x, y)
+				_, want := Div64(hi%m, lo, m)
+				if got := b.MulMod(x, y); got != want {
+					t.Errorf("NewBarrett64(%d).MulMod(%d, %d) == %d; want %d", m, x, y, got, want)
+				}
+			}
+		}
+	}
+	if err := divPanic(func() { NewBarrett64(0) }); err != ErrDivideByZero {
+		t.Errorf("NewBarrett64(0) panicked with %v; want %v", err, ErrDivideByZero)
+	}
+	if err := divPanic(func() { Barrett64{}.Reduce(1, 2) }); err != ErrDivideByZero {
+		t.Errorf("Barrett64{}.Reduce(1, 2) panicked with %v; want %v", err, ErrDivideByZero)
+	}
+}
+
+func TestMulHi128(t *testing.T) {
+	vals := u128Values()
+	for _, x := range vals {
+		for _, y := range vals {
+			z := new(big.Int).Mul(u128ToBig(x), u128ToBig(y))
+			if got, want := mulHi128(x, y), bigToU128(z.Rsh(z, 128)); got != want {
+				t.Errorf("mulHi128(%v, %v) == %v; want %v", x, y, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,403 @@
+// MIT License
+
+// Copyright (c) 2018 Akhil Indurti
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package extprec
+
+import . "math/bits"
+
+// The Divider types replace division by an invariant divisor with
+// a multiplication by a precomputed "magic" reciprocal and a shift.
+//
+// See "Hacker's Delight", Ch. 10: Integer Division by Constants,
+// and Granlund and Montgomery, "Division by invariant integers using
+// multiplication", PLDI 1994. The magic numbers are chosen as in
+// libdivide: a 64-bit magic number is used when one exists, and
+// otherwise the 65-bit magic number 2^64+m is emulated by adding
+// the dividend back in after the multiplication.
+
+// A Divider64 divides uint64 values by a fixed divisor.
+type Divider64 struct {
+	d   uint64 // divisor
+	m   uint64 // magic number, or zero if d is a power of two
+	s   uint   // post-shift
+	add bool   // whether the magic number is 2^64+m
+}
+
+// NewDivider64 returns a Divider64 for the divisor d.
+// NewDivider64 panics with ErrDivideByZero if d is zero.
+func NewDivider64(d uint64) Divider64 {
+	if d == 0 {
+		panic(ErrDivideByZero)
+	}
+	l := uint(63 - LeadingZeros64(d))
+	if d&(d-1) == 0 {
+		return Divider64{d: d, s: l}
+	}
+	// m = floor(2^(64+l)/d) is a candidate magic number
+	// for the shift l. It is exact enough if the error
+	// e = d - 2^(64+l) mod d is less than 2^l.
+	m, r := Div64(1<<l, 0, d)
+	if e := d - r; e < 1<<l {
+		return Divider64{d: d, m: m + 1, s: l}
+	}
+	// Otherwise use the shift l+1, whose magic number
+	// 2*m (+1) needs 65 bits.
+	m += m
+	if r2 := r + r; r2 >= d || r2 < r {
+		m++
+	}
+	return Divider64{d: d, m: m + 1, s: l, add: true}
+}
+
+// Divisor returns the divisor of d.
+func (d Divider64) Divisor() uint64 {
+	return d.d
+}
+
+// Quo returns the quotient n/d.
+func (d Divider64) Quo(n uint64) uint64 {
+	if d.m == 0 {
+		return n >> d.s
+	}
+	q, _ := Mul64(d.m, n)
+	if d.add {
+		// Compute (q + n) >> 1 without overflowing.
+		return ((n-q)>>1 + q) >> d.s
+	}
+	return q >> d.s
+}
+
+// Rem returns the remainder n%d.
+func (d Divider64) Rem(n uint64) uint64 {
+	return n - d.Quo(n)*d.d
+}
+
+// IsDivisible reports whether n is divisible by d.
+func (d Divider64) IsDivisible(n uint64) bool {
+	return d.Rem(n) == 0
+}
+
+// A Divider32 divides uint32 values by a fixed divisor.
+type Divider32 struct {
+	d   uint32 // divisor
+	m   uint32 // magic number, or zero if d is a power of two
+	s   uint   // post-shift
+	add bool   // whether the magic number is 2^32+m
+}
+
+// NewDivider32 returns a Divider32 for the divisor d.
+// NewDivider32 panics with ErrDivideByZero if d is zero.
+func NewDivider32(d uint32) Divider32 {
+	if d == 0 {
+		panic(ErrDivideByZero)
+	}
+	// See NewDivider64.
+	l := uint(31 - LeadingZeros32(d))
+	if d&(d-1) == 0 {
+		return Divider32{d: d, s: l}
+	}
+	m, r := Div32(1<<l, 0, d)
+	if e := d - r; e < 1<<l {
+		return Divider32{d: d, m: m + 1, s: l}
+	}
+	m += m
+	if r2 := r + r; r2 >= d || r2 < r {
+		m++
+	}
+	return Divider32{d: d, m: m + 1, s: l, add: true}
+}
+
+// Divisor returns the divisor of d.
+func (d Divider32) Divisor() uint32 {
+	return d.d
+}
+
+// Quo returns the quotient n/d.
+func (d Divider32) Quo(n uint32) uint32 {
+	if d.m == 0 {
+		return n >> d.s
+	}
+	q, _ := Mul32(d.m, n)
+	if d.add {
+		return ((n-q)>>1 + q) >> d.s
+	}
+	return q >> d.s
+}
+
+// Rem returns the remainder n%d.
+func (d Divider32) Rem(n uint32) uint32 {
+	return n - d.Quo(n)*d.d
+}
+
+// IsDivisible reports whether n is divisible by d.
+func (d Divider32) IsDivisible(n uint32) bool {
+	return d.Rem(n) == 0
+}
+
+// A DividerS64 divides int64 values by a fixed divisor,
+// truncating toward zero like Go's / and % operators.
+type DividerS64 struct {
+	d   int64 // divisor
+	m   int64 // magic number, or zero if |d| is a power of two
+	s   uint  // post-shift
+	add bool  // whether the dividend is added back after multiplying
+}
+
+// NewDividerS64 returns a DividerS64 for the divisor d.
+// NewDividerS64 panics with ErrDivideByZero if d is zero.
+func NewDividerS64(d int64) DividerS64 {
+	if d == 0 {
+		panic(ErrDivideByZero)
+	}
+	ad := uint64(d)
+	if d < 0 {
+		ad = -ad
+	}
+	l := uint(63 - LeadingZeros64(ad))
+	if ad&(ad-1) == 0 {
+		return DividerS64{d: d, s: l}
+	}
+	// As for NewDivider64, but with one bit less
+	// of magic number to leave room for the sign.
+	m, r := Div64(1<<(l-1), 0, ad)
+	div := DividerS64{d: d, s: l - 1}
+	if e := ad - r; e >= 1<<l {
+		m += m
+		if r2 := r + r; r2 >= ad || r2 < r {
+			m++
+		}
+		div.s, div.add = l, true
+	}
+	div.m = int64(m + 1)
+	if d < 0 {
+		div.m = -div.m
+	}
+	return div
+}
+
+// Divisor returns the divisor of d.
+func (d DividerS64) Divisor() int64 {
+	return d.d
+}
+
+// Quo returns the quotient n/d, truncated toward zero.
+// If n is the most negative int64 and d is -1, Quo returns n.
+func (d DividerS64) Quo(n int64) int64 {
+	if d.m == 0 {
+		// Bias negative dividends so that the
+		// arithmetic shift rounds toward zero.
+		q := (n + (n>>63)&(1<<d.s-1)) >> d.s
+		if d.d < 0 {
+			q = -q
+		}
+		return q
+	}
+	q, _ := MulS64(d.m, n)
+	if d.add {
+		if d.d < 0 {
+			q -= n
+		} else {
+			q += n
+		}
+	}
+	q >>= d.s
+	// Round a negative quotient toward zero.
+	return q + int64(uint64(q)>>63)
+}
+
+// Rem returns the remainder n%d, which has the sign of n.
+func (d DividerS64) Rem(n int64) int64 {
+	return n - d.Quo(n)*d.d
+}
+
+// IsDivisible reports whether n is divisible by d.
+func (d DividerS64) IsDivisible(n int64) bool {
+	return d.Rem(n) == 0
+}
+
+// A DividerS32 divides int32 values by a fixed divisor,
+// truncating toward zero like Go's / and % operators.
+type DividerS32 struct {
+	d   int32 // divisor
+	m   int32 // magic number, or zero if |d| is a power of two
+	s   uint  // post-shift
+	add bool  // whether the dividend is added back after multiplying
+}
+
+// NewDividerS32 returns a DividerS32 for the divisor d.
+// NewDividerS32 panics with ErrDivideByZero if d is zero.
+func NewDividerS32(d int32) DividerS32 {
+	if d == 0 {
+		panic(ErrDivideByZero)
+	}
+	// See NewDividerS64.
+	ad := uint32(d)
+	if d < 0 {
+		ad = -ad
+	}
+	l := uint(31 - LeadingZeros32(ad))
+	if ad&(ad-1) == 0 {
+		return DividerS32{d: d, s: l}
+	}
+	m, r := Div32(1<<(l-1), 0, ad)
+	div := DividerS32{d: d, s: l - 1}
+	if e := ad - r; e >= 1<<l {
+		m += m
+		if r2 := r + r; r2 >= ad || r2 < r {
+			m++
+		}
+		div.s, div.add = l, true
+	}
+	div.m = int32(m + 1)
+	if d < 0 {
+		div.m = -div.m
+	}
+	return div
+}
+
+// Divisor returns the divisor of d.
+func (d DividerS32) Divisor() int32 {
+	return d.d
+}
+
+// Quo returns the quotient n/d, truncated toward zero.
+// If n is the most negative int32 and d is -1, Quo returns n.
+func (d DividerS32) Quo(n int32) int32 {
+	if d.m == 0 {
+		q := (n + (n>>31)&(1<<d.s-1)) >> d.s
+		if d.d < 0 {
+			q = -q
+		}
+		return q
+	}
+	q, _ := MulS32(d.m, n)
+	if d.add {
+		if d.d < 0 {
+			q -= n
+		} else {
+			q += n
+		}
+	}
+	q >>= d.s
+	return q + int32(uint32(q)>>31)
+}
+
+// Rem returns the remainder n%d, which has the sign of n.
+func (d DividerS32) Rem(n int32) int32 {
+	return n - d.Quo(n)*d.d
+}
+
+// IsDivisible reports whether n is divisible by d.
+func (d DividerS32) IsDivisible(n int32) bool {
+	return d.Rem(n) == 0
+}
+
+// An ExactDivider64 tests uint64 values for divisibility by a fixed
+// divisor, and divides values known to be multiples of it, using
+// only multiplications by the inverse of the divisor modulo 2^64.
+type ExactDivider64 struct {
+	d    uint64 // divisor
+	odd  uint64 // odd part of d
+	inv  uint64 // inverse of odd modulo 2^64
+	max  uint64 // largest quotient of a uint64 by d
+	omax uint64 // largest quotient of a uint64 by odd
+	k    uint   // number of trailing zeros of d
+}
+
+// NewExactDivider64 returns an ExactDivider64 for the divisor d.
+// NewExactDivider64 panics with ErrDivideByZero if d is zero.
+func NewExactDivider64(d uint64) ExactDivider64 {
+	if d == 0 {
+		panic(ErrDivideByZero)
+	}
+	k := uint(TrailingZeros64(d))
+	odd := d >> k
+	return ExactDivider64{
+		d:    d,
+		odd:  odd,
+		inv:  Inverse64(odd),
+		max:  (1<<64 - 1) / d,
+		omax: (1<<64 - 1) / odd,
+		k:    k,
+	}
+}
+
+// Divisor returns the divisor of d.
+func (d ExactDivider64) Divisor() uint64 {
+	return d.d
+}
+
+// IsDivisible reports whether x is divisible by d.
+func (d ExactDivider64) IsDivisible(x uint64) bool {
+	// See "Hacker's Delight", Ch. 10-17: Test for Zero Remainder
+	// after Division by a Constant
+	//
+	// Multiplying by the inverse maps the multiples of the odd part
+	// of d one-to-one onto their quotients, and everything else above
+	// them. Rotating right by k moves the low k bits of the product,
+	// which are zero only for multiples of 2^k, to the top, beyond max.
+	return RotateLeft64(x*d.inv, -int(d.k)) <= d.max
+}
+
+// ExactQuo returns the quotient x/d, provided that x is
+// divisible by d. Otherwise, the result is meaningless.
+func (d ExactDivider64) ExactQuo(x uint64) uint64 {
+	return (x >> d.k) * d.inv
+}
+
+// IsDivisible128 reports whether (hi || lo) is divisible by d, where
+// hi and lo hold the most significant and least significant 64 bits
+// of the dividend.
+func (d ExactDivider64) IsDivisible128(hi, lo uint64) bool {
+	if lo&(1<<d.k-1) != 0 {
+		return false
+	}
+	hi, lo = d.shift(hi, lo)
+	// The low quotient word q0 is determined by lo. What remains of
+	// (hi || lo) - q0*d must then be a nonnegative multiple of d
+	// shifted up one word.
+	c, _ := Mul64(lo*d.inv, d.odd)
+	return hi >= c && (hi-c)*d.inv <= d.omax
+}
+
+// ExactQuo128 returns the quotient (qhi || qlo) of (hi || lo) and d,
+// where hi and lo hold the most significant and least significant
+// 64 bits of the dividend, provided that it is divisible by d.
+// Otherwise, the result is meaningless.
+func (d ExactDivider64) ExactQuo128(hi, lo uint64) (qhi, qlo uint64) {
+	// See Jebelean, "An algorithm for exact division",
+	// Journal of Symbolic Computation 15 (1993).
+	//
+	// Compute the quotient from its least significant word up,
+	// subtracting the high word of each partial product.
+	hi, lo = d.shift(hi, lo)
+	qlo = lo * d.inv
+	c, _ := Mul64(qlo, d.odd)
+	qhi = (hi - c) * d.inv
+	return
+}
+
+// shift returns (hi || lo) shifted right by the number of
+// trailing zeros of d.
+func (d ExactDivider64) shift(hi, lo uint64) (uint64, uint64) {
+	return hi >> d.k, lo>>d.k | hi<<(64-d.k)
+}
@@ -0,0 +1,226 @@
+// MIT License
+
+// Copyright (c) 2018 Akhil Indurti
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package extprec
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// dividends64 returns n pseudo-random dividends, plus
+// values near zero and the extremes of uint64.
+func dividends64(r *rand.Rand, n int) []uint64 {
+	x := []uint64{0, 1, 2, 3, 1<<32 - 1, 1 << 32, 1<<63 - 1, 1 << 63, 1<<63 + 1, 1<<64 - 2, 1<<64 - 1}
+	for i := 0; i < n; i++ {
+		x = append(x, r.Uint64()>>uint(r.Intn(64)))
+	}
+	return x
+}
+
+// divisors64 returns small divisors, powers of two and their
+// neighbors, and n pseudo-random divisors, all nonzero.
+func divisors64(r *rand.Rand, n int) []uint64 {
+	var d []uint64
+	for i := uint64(1); i <= 1000; i++ {
+		d = append(d, i)
+	}
+	for s := uint(1); s < 64; s++ {
+		d = append(d, 1<<s-1, 1<<s, 1<<s+1)
+	}
+	d = append(d, 1<<64-1, 1<<64-2, 641, 6700417, 0xFFFFFFFF00000001)
+	for want := len(d) + n; len(d) < want; {
+		if x := r.Uint64() >> uint(r.Intn(64)); x != 0 {
+			d = append(d, x)
+		}
+	}
+	return d
+}
+
+func TestDivider64(t *testing.T) {
+	r := rand.New(rand.NewSource(11))
+	ns := dividends64(r, 100)
+	for _, d := range divisors64(r, 1000) {
+		div := NewDivider64(d)
+		if div.Divisor() != d {
+			t.Errorf("NewDivider64(%d).Divisor() == %d", d, div.Divisor())
+		}
+		for _, n := range ns {
+			if q := div.Quo(n); q != n/d {
+				t.Errorf("NewDivider64(%d).Quo(%d) == %d; want %d", d, n, q, n/d)
+			}
+			if rem := div.Rem(n); rem != n%d {
+				t.Errorf("NewDivider64(%d).Rem(%d) == %d; want %d", d, n, rem, n%d)
+			}
+			if ok := div.IsDivisible(n); ok != (n%d == 0) {
+				t.Errorf("NewDivider64(%d).IsDivisible(%d) == %t; want %t", d, n, ok, n%d == 0)
+			}
+		}
+	}
+}
+
+func TestDivider32(t *testing.T) {
+	r := rand.New(rand.NewSource(12))
+	ns := dividends64(r, 100)
+	for _, d64 := range divisors64(r, 1000) {
+		d := uint32(d64)
+		if d == 0 {
+			continue
+		}
+		div := NewDivider32(d)
+		for _, n64 := range ns {
+			n := uint32(n64)
+			if q := div.Quo(n); q != n/d {
+				t.Errorf("NewDivider32(%d).Quo(%d) == %d; want %d", d, n, q, n/d)
+			}
+			if rem := div.Rem(n); rem != n%d {
+				t.Errorf("NewDivider32(%d).Rem(%d) == %d; want %d", d, n, rem, n%d)
+			}
+			if ok := div.IsDivisible(n); ok != (n%d == 0) {
+				t.Errorf("NewDivider32(%d).IsDivisible(%d) == %t; want %t", d, n, ok, n%d == 0)
+			}
+		}
+	}
+}
+
+func TestDividerS64(t *testing.T) {
+	r := rand.New(rand.NewSource(13))
+	ns := dividends64(r, 100)
+	for _, ud := range divisors64(r, 1000) {
+		for _, d := range []int64{int64(ud), -int64(ud)} {
+			div := NewDividerS64(d)
+			if div.Divisor() != d {
+				t.Errorf("NewDividerS64(%d).Divisor() == %d", d, div.Divisor())
+			}
+			for _, un := range ns {
+				for _, n := range []int64{int64(un), -int64(un)} {
+					if q := div.Quo(n); q != n/d {
+						t.Errorf("NewDividerS64(%d).Quo(%d) == %d; want %d", d, n, q, n/d)
+					}
+					if rem := div.Rem(n); rem != n%d {
+						t.Errorf("NewDividerS64(%d).Rem(%d) == %d; want %d", d, n, rem, n%d)
+					}
+					if ok := div.IsDivisible(n); ok != (n%d == 0) {
+						t.Errorf("NewDividerS64(%d).IsDivisible(%d) == %t; want %t", d, n, ok, n%d == 0)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestDividerS32(t *testing.T) {
+	r := rand.New(rand.NewSource(14))
+	ns := dividends64(r, 100)
+	for _, ud := range divisors64(r, 1000) {
+		for _, d := range []int32{int32(ud), -int32(ud)} {
+			if d == 0 {
+				continue
+			}
+			div := NewDividerS32(d)
+			for _, un := range ns {
+				for _, n := range []int32{int32(un), -int32(un)} {
+					if q := div.Quo(n); q != n/d {
+						t.Errorf("NewDividerS32(%d).Quo(%d) == %d; want %d", d, n, q, n/d)
+					}
+					if rem := div.Rem(n); rem != n%d {
+						t.Errorf("NewDividerS32(%d).Rem(%d) == %d; want %d", d, n, rem, n%d)
+					}
+					if ok := div.IsDivisible(n); ok != (n%d == 0) {
+						t.Errorf("NewDividerS32(%d).IsDivisible(%d) == %t; want %t", d, n, ok, n%d == 0)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestDividerZero(t *testing.T) {
+	for name, f := range map[string]func(){
+		"NewDivider64(0)":  func() { NewDivider64(0) },
+		"NewDivider32(0)":  func() { NewDivider32(0) },
+		"NewDividerS64(0)": func() { NewDividerS64(0) },
+		"NewDividerS32(0)": func() { NewDividerS32(0) },
+	} {
+		if err := divPanic(f); err != ErrDivideByZero {
+			t.Errorf("%s panicked with %v; want %v", name, err, ErrDivideByZero)
+		}
+	}
+}
+
+func TestExactDivider64(t *testing.T) {
+	r := rand.New(rand.NewSource(15))
+	ns := dividends64(r, 100)
+	for _, d := range divisors64(r, 1000) {
+		div := NewExactDivider64(d)
+		if div.Divisor() != d {
+			t.Errorf("NewExactDivider64(%d).Divisor() == %d", d, div.Divisor())
+		}
+		// Test each dividend, and the nearest multiple of d below it.
+		for _, n := range ns {
+			for _, x := range []uint64{n, n - n%d} {
+				if ok := div.IsDivisible(x); ok != (x%d == 0) {
+					t.Errorf("NewExactDivider64(%d).IsDivisible(%d) == %t; want %t", d, x, ok, x%d == 0)
+				}
+				if x%d == 0 {
+					if q := div.ExactQuo(x); q != x/d {
+						t.Errorf("NewExactDivider64(%d).ExactQuo(%d) == %d; want %d", d, x, q, x/d)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestExactDivider128(t *testing.T) {
+	r := rand.New(rand.NewSource(16))
+	ns := dividends64(r, 20)
+	for _, d := range divisors64(r, 200) {
+		div := NewExactDivider64(d)
+		for _, qhi := range ns {
+			for _, qlo := range ns {
+				_, rem := Uint128{qhi, qlo}.QuoRem(Uint128{0, d})
+				if ok := div.IsDivisible128(qhi, qlo); ok != rem.IsZero() {
+					t.Errorf("NewExactDivider64(%d).IsDivisible128(0x%X, 0x%X) == %t; want %t", d, qhi, qlo, ok, rem.IsZero())
+				}
+
+				// Build a multiple of d, and a neighboring
+				// value that is not a multiple.
+				q, _ := Uint128{qhi, qlo}.QuoRem(Uint128{0, d})
+				x := q.Mul(Uint128{0, d})
+				if !div.IsDivisible128(x.Hi, x.Lo) {
+					t.Errorf("NewExactDivider64(%d).IsDivisible128(0x%X, 0x%X) == false; want true", d, x.Hi, x.Lo)
+				}
+				if qh, ql := div.ExactQuo128(x.Hi, x.Lo); qh != q.Hi || ql != q.Lo {
+					t.Errorf("NewExactDivider64(%d).ExactQuo128(0x%X, 0x%X) == (0x%X, 0x%X); want (0x%X, 0x%X)", d, x.Hi, x.Lo, qh, ql, q.Hi, q.Lo)
+				}
+				y := x.Add(Uint128{0, 1})
+				if d == 1 || y.IsZero() {
+					continue
+				}
+				if div.IsDivisible128(y.Hi, y.Lo) {
+					t.Errorf("NewExactDivider64(%d).IsDivisible128(0x%X, 0x%X) == true; want false", d, y.Hi, y.Lo)
+				}
+			}
+		}
+	}
+}
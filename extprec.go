@@ -159,9 +159,109 @@ func Mul64(x, y uint64) (hi, lo uint64) {
 	return
 }
 
+// MulS returns the most significant and least significant
+// bits from the signed product of x and y.
+func MulS(x, y int) (hi int, lo uint) {
+	if UintSize == 32 {
+		hi32, lo32 := MulS32(int32(x), int32(y))
+		return int(hi32), uint(lo32)
+	}
+	hi64, lo64 := MulS64(int64(x), int64(y))
+	return int(hi64), uint(lo64)
+}
+
+// MulS32 returns the most significant and least significant
+// 32 bits from the signed 64-bit product of x and y.
+func MulS32(x, y int32) (hi int32, lo uint32) {
+	z := int64(x) * int64(y)
+	return int32(z >> 32), uint32(z)
+}
+
+// MulS64 returns the most significant and least significant
+// 64 bits from the signed 128-bit product of x and y.
+func MulS64(x, y int64) (hi int64, lo uint64) {
+	// See "Hacker's Delight", Ch. 8-3: High-Order Product Signed from/to Unsigned
+	//
+	// Interpreting a negative operand as unsigned adds 2^64 to it,
+	// which adds the other operand to the high word of the product.
+	// Subtract those contributions back out.
+
+	uhi, lo := Mul64(uint64(x), uint64(y))
+	hi = int64(uhi) - (x>>63)&y - (y>>63)&x
+	return
+}
+
+// MulOverflow returns the product of x and y
+// and reports whether it overflowed a uint.
+func MulOverflow(x, y uint) (product uint, overflow bool) {
+	hi, lo := Mul(x, y)
+	return lo, hi != 0
+}
+
+// MulOverflow32 returns the 32-bit product of x and y
+// and reports whether it overflowed a uint32.
+func MulOverflow32(x, y uint32) (product uint32, overflow bool) {
+	hi, lo := Mul32(x, y)
+	return lo, hi != 0
+}
+
+// MulOverflow64 returns the 64-bit product of x and y
+// and reports whether it overflowed a uint64.
+func MulOverflow64(x, y uint64) (product uint64, overflow bool) {
+	hi, lo := Mul64(x, y)
+	return lo, hi != 0
+}
+
+// MulOverflowS returns the signed product of x and y
+// and reports whether it overflowed an int.
+func MulOverflowS(x, y int) (product int, overflow bool) {
+	hi, lo := MulS(x, y)
+	product = int(lo)
+	// The product fits if the high word is just the
+	// sign extension of the low word.
+	return product, hi != product>>(UintSize-1)
+}
+
+// MulOverflowS32 returns the signed 32-bit product of x and y
+// and reports whether it overflowed an int32.
+func MulOverflowS32(x, y int32) (product int32, overflow bool) {
+	hi, lo := MulS32(x, y)
+	product = int32(lo)
+	return product, hi != product>>31
+}
+
+// MulOverflowS64 returns the signed 64-bit product of x and y
+// and reports whether it overflowed an int64.
+func MulOverflowS64(x, y int64) (product int64, overflow bool) {
+	hi, lo := MulS64(x, y)
+	product = int64(lo)
+	return product, hi != product>>63
+}
+
+// divError is the type of the run-time panics raised by the Div functions.
+type divError string
+
+func (e divError) Error() string { return string(e) }
+
+// RuntimeError marks divError as a run-time error,
+// like the panics raised by Go's own integer division.
+func (divError) RuntimeError() {}
+
+var (
+	// ErrDivideByZero is returned or raised as a panic
+	// when the divisor of a Div function is zero.
+	ErrDivideByZero error = divError("extprec: integer divide by zero")
+
+	// ErrQuotientOverflow is returned or raised as a panic
+	// when the quotient of a Div function does not fit in a word.
+	ErrQuotientOverflow error = divError("extprec: quotient overflow")
+)
+
 // Div returns the quotient and remainder of (hi || lo) and x,
 // where hi and lo hold the most significant and least
 // significant bits of the dividend.
+// Div panics with ErrDivideByZero if x is zero,
+// and with ErrQuotientOverflow if hi >= x.
 func Div(hi, lo, x uint) (quo, rem uint) {
 	if UintSize == 32 {
 		q32, r32 := Div32(uint32(hi), uint32(lo), uint32(x))
@@ -174,7 +274,12 @@ func Div(hi, lo, x uint) (quo, rem uint) {
 // Div32 returns the 32-bit quotient and remainder of (hi || lo) and x,
 // where hi and lo hold the most significant and least
 // significant 32 bits of the dividend.
+// Div32 panics with ErrDivideByZero if x is zero,
+// and with ErrQuotientOverflow if hi >= x.
 func Div32(hi, lo, x uint32) (quo, rem uint32) {
+	if err := checkDiv32(hi, x); err != nil {
+		panic(err)
+	}
 	y := (uint64(hi) << 32) | uint64(lo)
 	quo = uint32(y / uint64(x))
 	rem = uint32(y % uint64(x))
@@ -184,7 +289,12 @@ func Div32(hi, lo, x uint32) (quo, rem uint32) {
 // Div64 returns the 64-bit quotient and remainder of (hi || lo) and x,
 // where hi and lo hold the most significant and least
 // significant 64 bits of the dividend.
+// Div64 panics with ErrDivideByZero if x is zero,
+// and with ErrQuotientOverflow if hi >= x.
 func Div64(hi, lo, x uint64) (quo, rem uint64) {
+	if err := checkDiv64(hi, x); err != nil {
+		panic(err)
+	}
 	// See "Hacker's Delight", Ch. 9-4: Unsigned Long Division
 	const b = 1 << 32
 	s := LeadingZeros64(x)
@@ -229,3 +339,218 @@ func Div64(hi, lo, x uint64) (quo, rem uint64) {
 	rem = (un21*b + un0 - q0*x) >> us
 	return
 }
+
+// Quo returns the two-word quotient (qhi || qlo) of (hi || lo) and x,
+// where hi and lo hold the most significant and least significant
+// bits of the dividend. Unlike Div, hi may be greater than or equal to x.
+// Quo panics with ErrDivideByZero if x is zero.
+func Quo(hi, lo, x uint) (qhi, qlo uint) {
+	if UintSize == 32 {
+		qhi32, qlo32 := Quo32(uint32(hi), uint32(lo), uint32(x))
+		return uint(qhi32), uint(qlo32)
+	}
+	qhi64, qlo64 := Quo64(uint64(hi), uint64(lo), uint64(x))
+	return uint(qhi64), uint(qlo64)
+}
+
+// Quo32 returns the two-word quotient (qhi || qlo) of (hi || lo) and x,
+// where hi and lo hold the most significant and least significant
+// 32 bits of the dividend. Unlike Div32, hi may be greater than or equal to x.
+// Quo32 panics with ErrDivideByZero if x is zero.
+func Quo32(hi, lo, x uint32) (qhi, qlo uint32) {
+	if x == 0 {
+		panic(ErrDivideByZero)
+	}
+	q := ((uint64(hi) << 32) | uint64(lo)) / uint64(x)
+	return uint32(q >> 32), uint32(q)
+}
+
+// Quo64 returns the two-word quotient (qhi || qlo) of (hi || lo) and x,
+// where hi and lo hold the most significant and least significant
+// 64 bits of the dividend. Unlike Div64, hi may be greater than or equal to x.
+// Quo64 panics with ErrDivideByZero if x is zero.
+func Quo64(hi, lo, x uint64) (qhi, qlo uint64) {
+	if x == 0 {
+		panic(ErrDivideByZero)
+	}
+	// Divide one word at a time, as in long division.
+	qhi, hi = hi/x, hi%x
+	qlo, _ = Div64(hi, lo, x)
+	return
+}
+
+// Rem returns the remainder of (hi || lo) and x, where hi and lo
+// hold the most significant and least significant bits of the dividend.
+// Unlike Div, hi may be greater than or equal to x.
+// Rem panics with ErrDivideByZero if x is zero.
+func Rem(hi, lo, x uint) uint {
+	if UintSize == 32 {
+		return uint(Rem32(uint32(hi), uint32(lo), uint32(x)))
+	}
+	return uint(Rem64(uint64(hi), uint64(lo), uint64(x)))
+}
+
+// Rem32 returns the remainder of (hi || lo) and x, where hi and lo
+// hold the most significant and least significant 32 bits of the dividend.
+// Unlike Div32, hi may be greater than or equal to x.
+// Rem32 panics with ErrDivideByZero if x is zero.
+func Rem32(hi, lo, x uint32) uint32 {
+	if x == 0 {
+		panic(ErrDivideByZero)
+	}
+	return uint32(((uint64(hi) << 32) | uint64(lo)) % uint64(x))
+}
+
+// Rem64 returns the remainder of (hi || lo) and x, where hi and lo
+// hold the most significant and least significant 64 bits of the dividend.
+// Unlike Div64, hi may be greater than or equal to x.
+// Rem64 panics with ErrDivideByZero if x is zero.
+func Rem64(hi, lo, x uint64) uint64 {
+	if x == 0 {
+		panic(ErrDivideByZero)
+	}
+	// (hi || lo) and (hi%x || lo) are congruent modulo x.
+	_, rem := Div64(hi%x, lo, x)
+	return rem
+}
+
+// checkDiv32 returns the error, if any, of dividing (hi || lo) by x.
+func checkDiv32(hi, x uint32) error {
+	switch {
+	case x == 0:
+		return ErrDivideByZero
+	case hi >= x:
+		return ErrQuotientOverflow
+	}
+	return nil
+}
+
+// checkDiv64 returns the error, if any, of dividing (hi || lo) by x.
+func checkDiv64(hi, x uint64) error {
+	switch {
+	case x == 0:
+		return ErrDivideByZero
+	case hi >= x:
+		return ErrQuotientOverflow
+	}
+	return nil
+}
+
+// DivChecked returns the quotient and remainder of (hi || lo) and x,
+// where hi and lo hold the most significant and least
+// significant bits of the dividend.
+// Instead of panicking like Div, DivChecked returns ErrDivideByZero
+// if x is zero, and ErrQuotientOverflow if hi >= x.
+func DivChecked(hi, lo, x uint) (quo, rem uint, err error) {
+	if UintSize == 32 {
+		q32, r32, err := DivChecked32(uint32(hi), uint32(lo), uint32(x))
+		return uint(q32), uint(r32), err
+	}
+	q64, r64, err := DivChecked64(uint64(hi), uint64(lo), uint64(x))
+	return uint(q64), uint(r64), err
+}
+
+// DivChecked32 returns the 32-bit quotient and remainder of (hi || lo) and x,
+// where hi and lo hold the most significant and least
+// significant 32 bits of the dividend.
+// Instead of panicking like Div32, DivChecked32 returns ErrDivideByZero
+// if x is zero, and ErrQuotientOverflow if hi >= x.
+func DivChecked32(hi, lo, x uint32) (quo, rem uint32, err error) {
+	if err = checkDiv32(hi, x); err != nil {
+		return
+	}
+	quo, rem = Div32(hi, lo, x)
+	return
+}
+
+// DivChecked64 returns the 64-bit quotient and remainder of (hi || lo) and x,
+// where hi and lo hold the most significant and least
+// significant 64 bits of the dividend.
+// Instead of panicking like Div64, DivChecked64 returns ErrDivideByZero
+// if x is zero, and ErrQuotientOverflow if hi >= x.
+func DivChecked64(hi, lo, x uint64) (quo, rem uint64, err error) {
+	if err = checkDiv64(hi, x); err != nil {
+		return
+	}
+	quo, rem = Div64(hi, lo, x)
+	return
+}
+
+// DivS returns the signed quotient and remainder of (hi || lo) and x,
+// where hi and lo hold the most significant and least significant
+// bits of the two's complement dividend. The quotient is truncated
+// toward zero, and the remainder has the sign of the dividend.
+// If the quotient does not fit in an int, overflow is set and quo
+// holds its least significant bits.
+// DivS panics with ErrDivideByZero if x is zero.
+func DivS(hi int, lo uint, x int) (quo, rem int, overflow bool) {
+	if UintSize == 32 {
+		q32, r32, o := DivS32(int32(hi), uint32(lo), int32(x))
+		return int(q32), int(r32), o
+	}
+	q64, r64, o := DivS64(int64(hi), uint64(lo), int64(x))
+	return int(q64), int(r64), o
+}
+
+// DivS32 returns the signed 32-bit quotient and remainder of (hi || lo)
+// and x, where hi and lo hold the most significant and least significant
+// 32 bits of the two's complement dividend. The quotient is truncated
+// toward zero, and the remainder has the sign of the dividend.
+// If the quotient does not fit in an int32, overflow is set and quo
+// holds its least significant 32 bits.
+// DivS32 panics with ErrDivideByZero if x is zero.
+func DivS32(hi int32, lo uint32, x int32) (quo, rem int32, overflow bool) {
+	if x == 0 {
+		panic(ErrDivideByZero)
+	}
+	y := int64(hi)<<32 | int64(lo)
+	q := y / int64(x)
+	quo = int32(q)
+	rem = int32(y % int64(x))
+	overflow = int64(quo) != q
+	return
+}
+
+// DivS64 returns the signed 64-bit quotient and remainder of (hi || lo)
+// and x, where hi and lo hold the most significant and least significant
+// 64 bits of the two's complement dividend. The quotient is truncated
+// toward zero, and the remainder has the sign of the dividend.
+// If the quotient does not fit in an int64, overflow is set and quo
+// holds its least significant 64 bits.
+// DivS64 panics with ErrDivideByZero if x is zero.
+func DivS64(hi int64, lo uint64, x int64) (quo, rem int64, overflow bool) {
+	// Divide the magnitudes, then restore the signs.
+	// The magnitude of the most negative dividend, 2^127,
+	// and divisor, 2^63, are representable as unsigned values.
+	neg := hi < 0
+	uhi, ulo := uint64(hi), lo
+	if neg {
+		var b uint64
+		ulo, b = Sub64(0, ulo, 0)
+		uhi, _ = Sub64(0, uhi, b)
+	}
+	ux := uint64(x)
+	if x < 0 {
+		ux = -ux
+	}
+
+	// Divide in two steps if the quotient needs more than 64 bits.
+	var qhi uint64
+	if uhi >= ux {
+		qhi, uhi = Div64(0, uhi, ux)
+	}
+	q, r := Div64(uhi, ulo, ux)
+
+	if neg != (x < 0) {
+		overflow = qhi != 0 || q > 1<<63
+		quo = -int64(q)
+	} else {
+		overflow = qhi != 0 || q > 1<<63-1
+		quo = int64(q)
+	}
+	rem = int64(r)
+	if neg {
+		rem = -rem
+	}
+	return
+}
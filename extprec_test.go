@@ -24,7 +24,13 @@
 
 package extprec
 
-import "testing"
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"runtime"
+	"testing"
+)
 
 func TestAdd(t *testing.T) {
 	t.Run("edge", edgeAdd)
@@ -316,6 +322,53 @@ func TestMul64(t *testing.T) {
 	}
 }
 
+// signed64 holds signed operands around zero and the extremes of int64.
+var signed64 = []int64{
+	0, 1, -1, 2, -2, 3, -3, 0xFF, -0xFF,
+	1<<31 - 1, -1 << 31, 1 << 32, -1 << 32,
+	1<<62 + 1, -1<<62 - 1, 1<<63 - 2, 1<<63 - 1, -1<<63 + 1, -1 << 63,
+}
+
+func TestMulS32(t *testing.T) {
+	for _, x := range signed64 {
+		for _, y := range signed64 {
+			x32, y32 := int32(x), int32(y)
+			z := int64(x32) * int64(y32)
+			hi, lo := MulS32(x32, y32)
+			if hi != int32(z>>32) || lo != uint32(z) {
+				t.Errorf("MulS32(%d, %d) == (0x%X, 0x%X); want (0x%X, 0x%X)", x32, y32, hi, lo, int32(z>>32), uint32(z))
+			}
+		}
+	}
+}
+
+func TestMulS64(t *testing.T) {
+	mask := new(big.Int).SetUint64(1<<64 - 1)
+	for _, x := range signed64 {
+		for _, y := range signed64 {
+			z := new(big.Int).Mul(big.NewInt(x), big.NewInt(y))
+			wantHi := new(big.Int).Rsh(z, 64).Int64()
+			wantLo := new(big.Int).And(z, mask).Uint64()
+			hi, lo := MulS64(x, y)
+			if hi != wantHi || lo != wantLo {
+				t.Errorf("MulS64(%d, %d) == (0x%X, 0x%X); want (0x%X, 0x%X)", x, y, hi, lo, wantHi, wantLo)
+			}
+			h, l := MulS(int(x), int(y))
+			var wh int
+			var wl uint
+			if bits.UintSize == 32 {
+				h32, l32 := MulS32(int32(x), int32(y))
+				wh, wl = int(h32), uint(l32)
+			} else {
+				wh, wl = int(wantHi), uint(wantLo)
+			}
+			if h != wh || l != wl {
+				t.Errorf("MulS(%d, %d) == (0x%X, 0x%X); want (0x%X, 0x%X)", int(x), int(y), h, l, wh, wl)
+			}
+		}
+	}
+}
+
 func TestDiv32(t *testing.T) {
 	for _, f := range factors32 {
 		if f.x != 0 {
@@ -349,3 +402,246 @@ func TestDiv64(t *testing.T) {
 		}
 	}
 }
+
+func TestDivS64(t *testing.T) {
+	los := []uint64{0, 1, 0xFF, 1<<63 - 1, 1 << 63, 1<<64 - 1}
+	mask := new(big.Int).SetUint64(1<<64 - 1)
+	minInt64, maxInt64 := big.NewInt(-1<<63), big.NewInt(1<<63-1)
+	for _, hi := range signed64 {
+		for _, lo := range los {
+			y := new(big.Int).Lsh(big.NewInt(hi), 64)
+			y.Or(y, new(big.Int).SetUint64(lo))
+			for _, x := range signed64 {
+				if x == 0 {
+					continue
+				}
+				q, r := new(big.Int).QuoRem(y, big.NewInt(x), new(big.Int))
+				wantOverflow := q.Cmp(minInt64) < 0 || q.Cmp(maxInt64) > 0
+				wantQuo := int64(new(big.Int).And(q, mask).Uint64())
+				wantRem := r.Int64()
+				quo, rem, overflow := DivS64(hi, lo, x)
+				if quo != wantQuo || rem != wantRem || overflow != wantOverflow {
+					t.Errorf("DivS64(%d, 0x%X, %d) == (%d, %d, %t); want (%d, %d, %t)", hi, lo, x, quo, rem, overflow, wantQuo, wantRem, wantOverflow)
+				}
+				if bits.UintSize == 64 {
+					q, r, o := DivS(int(hi), uint(lo), int(x))
+					if q != int(quo) || r != int(rem) || o != overflow {
+						t.Errorf("DivS(%d, 0x%X, %d) == (%d, %d, %t); want (%d, %d, %t)", hi, lo, x, q, r, o, quo, rem, overflow)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestDivS32(t *testing.T) {
+	los := []uint32{0, 1, 0xFF, 1<<31 - 1, 1 << 31, 1<<32 - 1}
+	for _, h := range signed64 {
+		for _, lo := range los {
+			hi := int32(h)
+			y := int64(hi)<<32 | int64(lo)
+			for _, v := range signed64 {
+				x := int32(v)
+				if x == 0 {
+					continue
+				}
+				q, r := new(big.Int).QuoRem(big.NewInt(y), big.NewInt(int64(x)), new(big.Int))
+				wantOverflow := !q.IsInt64() || q.Int64() != int64(int32(q.Int64()))
+				wantQuo := int32(new(big.Int).And(q, big.NewInt(1<<32-1)).Int64())
+				wantRem := int32(r.Int64())
+				quo, rem, overflow := DivS32(hi, lo, x)
+				if quo != wantQuo || rem != wantRem || overflow != wantOverflow {
+					t.Errorf("DivS32(%d, 0x%X, %d) == (%d, %d, %t); want (%d, %d, %t)", hi, lo, x, quo, rem, overflow, wantQuo, wantRem, wantOverflow)
+				}
+				if bits.UintSize == 32 {
+					q, r, o := DivS(int(hi), uint(lo), int(x))
+					if q != int(quo) || r != int(rem) || o != overflow {
+						t.Errorf("DivS(%d, 0x%X, %d) == (%d, %d, %t); want (%d, %d, %t)", hi, lo, x, q, r, o, quo, rem, overflow)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestDivChecked(t *testing.T) {
+	for _, f := range factors64 {
+		if f.x == 0 {
+			continue
+		}
+		quo, rem, err := DivChecked64(f.hi, f.lo, f.x)
+		if quo != f.y || rem != f.rem || err != nil {
+			t.Errorf("DivChecked64(0x%X, 0x%X, 0x%X) == (0x%X, 0x%X, %v); want (0x%X, 0x%X, <nil>)", f.hi, f.lo, f.x, quo, rem, err, f.y, f.rem)
+		}
+	}
+	for _, f := range factors32 {
+		if f.x == 0 {
+			continue
+		}
+		quo, rem, err := DivChecked32(f.hi, f.lo, f.x)
+		if quo != f.y || rem != f.rem || err != nil {
+			t.Errorf("DivChecked32(0x%X, 0x%X, 0x%X) == (0x%X, 0x%X, %v); want (0x%X, 0x%X, <nil>)", f.hi, f.lo, f.x, quo, rem, err, f.y, f.rem)
+		}
+	}
+	invalid := []struct {
+		hi, lo, x uint64
+		err       error
+	}{
+		{0, 0, 0, ErrDivideByZero},
+		{0, 1<<64 - 1, 0, ErrDivideByZero},
+		{1, 0, 1, ErrQuotientOverflow},
+		{5, 0, 5, ErrQuotientOverflow},
+		{0xFFFFFFFF, 0, 0xFFFFFFFF, ErrQuotientOverflow},
+		{1<<64 - 1, 1<<64 - 1, 1<<64 - 1, ErrQuotientOverflow},
+	}
+	for _, c := range invalid {
+		if _, _, err := DivChecked64(c.hi, c.lo, c.x); err != c.err {
+			t.Errorf("DivChecked64(0x%X, 0x%X, 0x%X) returned error %v; want %v", c.hi, c.lo, c.x, err, c.err)
+		}
+		if _, _, err := DivChecked32(uint32(c.hi), uint32(c.lo), uint32(c.x)); err != c.err {
+			t.Errorf("DivChecked32(0x%X, 0x%X, 0x%X) returned error %v; want %v", uint32(c.hi), uint32(c.lo), uint32(c.x), err, c.err)
+		}
+		if _, _, err := DivChecked(uint(c.hi), uint(c.lo), uint(c.x)); err != c.err {
+			t.Errorf("DivChecked(0x%X, 0x%X, 0x%X) returned error %v; want %v", uint(c.hi), uint(c.lo), uint(c.x), err, c.err)
+		}
+		if err := divPanic(func() { Div64(c.hi, c.lo, c.x) }); err != c.err {
+			t.Errorf("Div64(0x%X, 0x%X, 0x%X) panicked with %v; want %v", c.hi, c.lo, c.x, err, c.err)
+		}
+		if err := divPanic(func() { Div32(uint32(c.hi), uint32(c.lo), uint32(c.x)) }); err != c.err {
+			t.Errorf("Div32(0x%X, 0x%X, 0x%X) panicked with %v; want %v", uint32(c.hi), uint32(c.lo), uint32(c.x), err, c.err)
+		}
+		if err := divPanic(func() { Div(uint(c.hi), uint(c.lo), uint(c.x)) }); err != c.err {
+			t.Errorf("Div(0x%X, 0x%X, 0x%X) panicked with %v; want %v", uint(c.hi), uint(c.lo), uint(c.x), err, c.err)
+		}
+	}
+}
+
+func TestDivSZero(t *testing.T) {
+	if err := divPanic(func() { DivS64(-1, 0, 0) }); err != ErrDivideByZero {
+		t.Errorf("DivS64(-1, 0, 0) panicked with %v; want %v", err, ErrDivideByZero)
+	}
+	if err := divPanic(func() { DivS32(-1, 0, 0) }); err != ErrDivideByZero {
+		t.Errorf("DivS32(-1, 0, 0) panicked with %v; want %v", err, ErrDivideByZero)
+	}
+}
+
+// divPanic calls f and returns the value it panicked with, if any.
+func divPanic(f func()) (err interface{}) {
+	defer func() {
+		err = recover()
+		if _, ok := err.(runtime.Error); err != nil && !ok {
+			err = fmt.Errorf("non-runtime error %v", err)
+		}
+	}()
+	f()
+	return nil
+}
+
+func TestMulOverflow(t *testing.T) {
+	for _, f := range factors32 {
+		if f.rem != 0 {
+			continue
+		}
+		hi, lo := Mul32(f.x, f.y)
+		if p, o := MulOverflow32(f.x, f.y); p != lo || o != (hi != 0) {
+			t.Errorf("MulOverflow32(0x%X, 0x%X) == (0x%X, %t); want (0x%X, %t)", f.x, f.y, p, o, lo, hi != 0)
+		}
+		if bits.UintSize == 32 {
+			if p, o := MulOverflow(uint(f.x), uint(f.y)); p != uint(lo) || o != (hi != 0) {
+				t.Errorf("MulOverflow(0x%X, 0x%X) == (0x%X, %t); want (0x%X, %t)", f.x, f.y, p, o, lo, hi != 0)
+			}
+		}
+	}
+	for _, f := range factors64 {
+		if f.rem != 0 {
+			continue
+		}
+		hi, lo := Mul64(f.x, f.y)
+		if p, o := MulOverflow64(f.x, f.y); p != lo || o != (hi != 0) {
+			t.Errorf("MulOverflow64(0x%X, 0x%X) == (0x%X, %t); want (0x%X, %t)", f.x, f.y, p, o, lo, hi != 0)
+		}
+		if bits.UintSize == 64 {
+			if p, o := MulOverflow(uint(f.x), uint(f.y)); p != uint(lo) || o != (hi != 0) {
+				t.Errorf("MulOverflow(0x%X, 0x%X) == (0x%X, %t); want (0x%X, %t)", f.x, f.y, p, o, lo, hi != 0)
+			}
+		}
+	}
+}
+
+func TestMulOverflowS(t *testing.T) {
+	for _, x := range signed64 {
+		for _, y := range signed64 {
+			// The product fits if the full-width result
+			// survives truncation to a single word.
+			z := int64(int32(x)) * int64(int32(y))
+			if p, o := MulOverflowS32(int32(x), int32(y)); p != int32(z) || o != (z != int64(int32(z))) {
+				t.Errorf("MulOverflowS32(%d, %d) == (%d, %t); want (%d, %t)", int32(x), int32(y), p, o, int32(z), z != int64(int32(z)))
+			}
+			_, lo := MulS64(x, y)
+			want := !new(big.Int).Mul(big.NewInt(x), big.NewInt(y)).IsInt64()
+			if p, o := MulOverflowS64(x, y); p != int64(lo) || o != want {
+				t.Errorf("MulOverflowS64(%d, %d) == (%d, %t); want (%d, %t)", x, y, p, o, int64(lo), want)
+			}
+			if bits.UintSize == 64 {
+				if p, o := MulOverflowS(int(x), int(y)); p != int(lo) || o != want {
+					t.Errorf("MulOverflowS(%d, %d) == (%d, %t); want (%d, %t)", x, y, p, o, int64(lo), want)
+				}
+			}
+		}
+	}
+}
+
+func TestQuoRem(t *testing.T) {
+	words := []uint64{0, 1, 2, 3, 0xFF, 1<<32 - 1, 1 << 32, 1<<63 - 1, 1 << 63, 1<<64 - 2, 1<<64 - 1}
+	for _, hi := range words {
+		for _, lo := range words {
+			for _, x := range words {
+				if x == 0 {
+					continue
+				}
+				bq, br := new(big.Int).QuoRem(u128ToBig(Uint128{hi, lo}), new(big.Int).SetUint64(x), new(big.Int))
+				q, r := bigToU128(bq), br.Uint64()
+				if qhi, qlo := Quo64(hi, lo, x); qhi != q.Hi || qlo != q.Lo {
+					t.Errorf("Quo64(0x%X, 0x%X, 0x%X) == (0x%X, 0x%X); want (0x%X, 0x%X)", hi, lo, x, qhi, qlo, q.Hi, q.Lo)
+				}
+				if rem := Rem64(hi, lo, x); rem != r {
+					t.Errorf("Rem64(0x%X, 0x%X, 0x%X) == 0x%X; want 0x%X", hi, lo, x, rem, r)
+				}
+
+				hi32, lo32, x32 := uint32(hi), uint32(lo), uint32(x)
+				if x32 == 0 {
+					continue
+				}
+				y := uint64(hi32)<<32 | uint64(lo32)
+				wq, wr := y/uint64(x32), uint32(y%uint64(x32))
+				if qhi, qlo := Quo32(hi32, lo32, x32); qhi != uint32(wq>>32) || qlo != uint32(wq) {
+					t.Errorf("Quo32(0x%X, 0x%X, 0x%X) == (0x%X, 0x%X); want (0x%X, 0x%X)", hi32, lo32, x32, qhi, qlo, uint32(wq>>32), uint32(wq))
+				}
+				if rem := Rem32(hi32, lo32, x32); rem != wr {
+					t.Errorf("Rem32(0x%X, 0x%X, 0x%X) == 0x%X; want 0x%X", hi32, lo32, x32, rem, wr)
+				}
+
+				wantQhi, wantQlo, wantRem := uint(q.Hi), uint(q.Lo), uint(r)
+				if bits.UintSize == 32 {
+					wantQhi, wantQlo, wantRem = uint(wq>>32), uint(uint32(wq)), uint(wr)
+				}
+				if qhi, qlo := Quo(uint(hi), uint(lo), uint(x)); qhi != wantQhi || qlo != wantQlo {
+					t.Errorf("Quo(0x%X, 0x%X, 0x%X) == (0x%X, 0x%X); want (0x%X, 0x%X)", uint(hi), uint(lo), uint(x), qhi, qlo, wantQhi, wantQlo)
+				}
+				if rem := Rem(uint(hi), uint(lo), uint(x)); rem != wantRem {
+					t.Errorf("Rem(0x%X, 0x%X, 0x%X) == 0x%X; want 0x%X", uint(hi), uint(lo), uint(x), rem, wantRem)
+				}
+			}
+		}
+	}
+	for name, f := range map[string]func(){
+		"Quo64(1, 0, 0)": func() { Quo64(1, 0, 0) },
+		"Quo32(1, 0, 0)": func() { Quo32(1, 0, 0) },
+		"Rem64(1, 0, 0)": func() { Rem64(1, 0, 0) },
+		"Rem32(1, 0, 0)": func() { Rem32(1, 0, 0) },
+	} {
+		if err := divPanic(f); err != ErrDivideByZero {
+			t.Errorf("%s panicked with %v; want %v", name, err, ErrDivideByZero)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// MIT License
+
+// Copyright (c) 2018 Akhil Indurti
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package extprec
+
+import "sort"
+
+// Factor64 returns the prime factors of n in ascending order,
+// repeated according to their multiplicity. Factor64 returns
+// nil if n < 2.
+func Factor64(n uint64) []uint64 {
+	if n < 2 {
+		return nil
+	}
+	var f []uint64
+	for _, p := range primeBases {
+		for n%p == 0 {
+			f = append(f, p)
+			n /= p
+		}
+	}
+	if n > 1 {
+		f = factorOdd(f, n)
+		sort.Slice(f, func(i, j int) bool { return f[i] < f[j] })
+	}
+	return f
+}
+
+// factorOdd appends the prime factors of n to f, where
+// n > 1 has no prime factor less than 41.
+func factorOdd(f []uint64, n uint64) []uint64 {
+	if n < 41*41 || IsPrime64(n) {
+		return append(f, n)
+	}
+	d := pollardBrent(n)
+	return factorOdd(factorOdd(f, d), n/d)
+}
+
+// pollardBrent returns a nontrivial factor of the odd composite n.
+func pollardBrent(n uint64) uint64 {
+	// See Brent, "An improved Monte Carlo factorization algorithm",
+	// BIT 20 (1980).
+	//
+	// Iterate x -> x^2 + c in Montgomery form, which permutes the
+	// residues mod n without changing their gcd with n. The differences
+	// are accumulated in a product to share one gcd among many steps.
+	const m = 128
+	mg := NewMontgomery64(n)
+	diff := func(x, y uint64) uint64 {
+		if x < y {
+			return y - x
+		}
+		return x - y
+	}
+	for c := mg.ToMont(1); ; c = mg.Add(c, mg.ToMont(1)) {
+		f := func(x uint64) uint64 { return mg.Add(mg.Mul(x, x), c) }
+		x, y, ys := c, c, c
+		q, g := mg.ToMont(1), uint64(1)
+		for r := 1; g == 1; r <<= 1 {
+			x = y
+			for i := 0; i < r; i++ {
+				y = f(y)
+			}
+			for k := 0; k < r && g == 1; k += m {
+				ys = y
+				for i := 0; i < m && i < r-k; i++ {
+					y = f(y)
+					q = mg.Mul(q, diff(x, y))
+				}
+				g = GCD64(q, n)
+			}
+		}
+		if g == n {
+			// The product reached a multiple of n; retrace the
+			// last batch one step at a time.
+			for g = 1; g == 1; {
+				ys = f(ys)
+				g = GCD64(diff(x, ys), n)
+			}
+		}
+		if g != n {
+			return g
+		}
+	}
+}
@@ -0,0 +1,64 @@
+// MIT License
+
+// Copyright (c) 2018 Akhil Indurti
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package extprec
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestFactor64(t *testing.T) {
+	r := rand.New(rand.NewSource(26))
+	n := []uint64{0, 1, 2, 4, 41 * 41, 1 << 63, 1<<64 - 1, 1<<64 - 59, 37 * 41 * 43}
+	n = append(n, strongPseudoprimes...)
+	for i := 0; i < 30; i++ {
+		// Products of two or three primes of similar size,
+		// and powers of a prime.
+		p := NextPrime64(r.Uint64() >> 32)
+		q := NextPrime64(r.Uint64() >> 32)
+		s := NextPrime64(r.Uint64() >> 43)
+		n = append(n, p*q, p*p, s*s*s, s*NextPrime64(s)*PrevPrime64(s))
+	}
+	n = append(n, moduli64(r, 200)...)
+	for _, x := range n {
+		f := Factor64(x)
+		if x < 2 {
+			if f != nil {
+				t.Errorf("Factor64(%d) == %v; want nil", x, f)
+			}
+			continue
+		}
+		prod := uint64(1)
+		for i, p := range f {
+			if !new(big.Int).SetUint64(p).ProbablyPrime(0) || i > 0 && p < f[i-1] {
+				t.Errorf("Factor64(%d) == %v; not ascending primes", x, f)
+				break
+			}
+			prod *= p
+		}
+		if prod != x {
+			t.Errorf("Factor64(%d) == %v; product is %d", x, f, prod)
+		}
+	}
+}
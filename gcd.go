@@ -0,0 +1,117 @@
+// MIT License
+
+// Copyright (c) 2018 Akhil Indurti
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package extprec
+
+import . "math/bits"
+
+// GCD64 returns the greatest common divisor of a and b.
+// GCD64(a, 0) and GCD64(0, a) return a.
+func GCD64(a, b uint64) uint64 {
+	// See Knuth, "The Art of Computer Programming", Vol. 2, Section 4.5.2,
+	// Algorithm B (Stein's binary GCD algorithm).
+	//
+	// Factor out the common powers of two, then repeatedly subtract
+	// the smaller odd number from the larger, and strip the factors
+	// of two from the difference, until it reaches zero.
+	if a == 0 {
+		return b
+	}
+	if b == 0 {
+		return a
+	}
+	k := uint(TrailingZeros64(a | b))
+	a >>= uint(TrailingZeros64(a))
+	for b != 0 {
+		b >>= uint(TrailingZeros64(b))
+		if a > b {
+			a, b = b, a
+		}
+		b -= a
+	}
+	return a << k
+}
+
+// GCD128 returns the greatest common divisor (hi || lo) of (ahi || alo)
+// and (bhi || blo), where each pair holds the most significant and least
+// significant 64 bits of a value.
+func GCD128(ahi, alo, bhi, blo uint64) (hi, lo uint64) {
+	// See Jebelean, "Improving the multiprecision Euclidean algorithm",
+	// DISCO '93, and Knuth, "The Art of Computer Programming", Vol. 2,
+	// Section 4.5.2, Algorithm L (Lehmer's algorithm).
+	//
+	// While both values need two words, simulate as many steps of
+	// Euclid's algorithm as possible on their leading 64 bits, then
+	// apply them all at once. Once the smaller value fits in a word,
+	// a single division reduces the problem to GCD64.
+	a, b := Uint128{ahi, alo}, Uint128{bhi, blo}
+	if a.Cmp(b) < 0 {
+		a, b = b, a
+	}
+	for b.Hi != 0 {
+		u0, u1, v0, v1, even := lehmerSimulate(a, b)
+		switch {
+		case v0 == 0:
+			// No quotient could be simulated; take a full Euclidean step.
+			_, r := a.QuoRem(b)
+			a, b = b, r
+		case even:
+			// a, b = u0*a - v0*b, v1*b - u1*a
+			a, b = a.mul64(u0).Sub(b.mul64(v0)), b.mul64(v1).Sub(a.mul64(u1))
+		default:
+			// a, b = v0*b - u0*a, u1*a - v1*b
+			a, b = b.mul64(v0).Sub(a.mul64(u0)), a.mul64(u1).Sub(b.mul64(v1))
+		}
+	}
+	if b.Lo == 0 {
+		return a.Hi, a.Lo
+	}
+	return 0, GCD64(b.Lo, Rem64(a.Hi, a.Lo, b.Lo))
+}
+
+// lehmerSimulate runs Euclid's algorithm on the leading 64 bits of
+// a >= b, and returns the cosequences that map (a, b) to the values
+// after the last step that is guaranteed to agree with the full values.
+// For even, u0, v1 >= 0 and u1, v0 <= 0; otherwise the signs are
+// reversed. The magnitudes of the cosequences are returned.
+func lehmerSimulate(a, b Uint128) (u0, u1, v0, v1 uint64, even bool) {
+	// Extract the leading 64 bits of both values, aligned by
+	// the position of the most significant bit of a.
+	h := uint(LeadingZeros64(a.Hi))
+	a1 := a.Lsh(h).Hi
+	a2 := b.Lsh(h).Hi
+
+	// Use Collins' stopping condition, which guarantees that the
+	// simulated quotients are correct. The cosequences are bounded
+	// by the remainders, so none of this overflows.
+	var u2, v2 uint64
+	u0, u1, u2 = 0, 1, 0
+	v0, v1, v2 = 0, 0, 1
+	for a2 >= v2 && a1-a2 >= v1+v2 {
+		q, r := a1/a2, a1%a2
+		a1, a2 = a2, r
+		u0, u1, u2 = u1, u2, u1+q*u2
+		v0, v1, v2 = v1, v2, v1+q*v2
+		even = !even
+	}
+	return
+}
@@ -0,0 +1,67 @@
+// MIT License
+
+// Copyright (c) 2018 Akhil Indurti
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package extprec
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestGCD64(t *testing.T) {
+	r := rand.New(rand.NewSource(24))
+	vals := append([]uint64{0}, moduli64(r, 100)...)
+	for i := 0; i < 50; i++ {
+		// Values with a large common factor.
+		g := r.Uint64() >> uint(r.Intn(64))
+		vals = append(vals, g*uint64(r.Intn(1000)))
+	}
+	for _, a := range vals {
+		for _, b := range vals {
+			want := new(big.Int).GCD(nil, nil, new(big.Int).SetUint64(a), new(big.Int).SetUint64(b)).Uint64()
+			if got := GCD64(a, b); got != want {
+				t.Errorf("GCD64(%d, %d) == %d; want %d", a, b, got, want)
+			}
+		}
+	}
+}
+
+func TestGCD128(t *testing.T) {
+	r := rand.New(rand.NewSource(25))
+	vals := u128Values()
+	for i := 0; i < 100; i++ {
+		// Values with a large common factor, which
+		// take many steps to reduce to a single word.
+		g := Uint128{r.Uint64() >> uint(r.Intn(64)), r.Uint64()}
+		x := Uint128{0, r.Uint64() >> uint(r.Intn(64))}
+		vals = append(vals, g.Mul(x).Rsh(uint(r.Intn(2))), Uint128{r.Uint64(), r.Uint64()})
+	}
+	for _, a := range vals {
+		for _, b := range vals {
+			want := bigToU128(new(big.Int).GCD(nil, nil, u128ToBig(a), u128ToBig(b)))
+			if hi, lo := GCD128(a.Hi, a.Lo, b.Hi, b.Lo); hi != want.Hi || lo != want.Lo {
+				t.Errorf("GCD128(%v, %v) == (0x%X, 0x%X); want %v", a, b, hi, lo, want)
+			}
+		}
+	}
+}
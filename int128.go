@@ -0,0 +1,184 @@
+// MIT License
+
+// Copyright (c) 2018 Akhil Indurti
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package extprec
+
+// An Int128 represents a signed 128-bit integer in two's complement.
+// Hi and Lo hold the most significant and least significant 64 bits
+// of the value; the sign is carried by Hi.
+//
+// Arithmetic on an Int128 wraps around on overflow, just as it does
+// for Go's built-in signed integers. In particular, the most negative
+// value -2^127 is its own negation.
+// The zero value is 0.
+type Int128 struct {
+	Hi int64
+	Lo uint64
+}
+
+// u returns the bits of x as a Uint128.
+func (x Int128) u() Uint128 {
+	return Uint128{uint64(x.Hi), x.Lo}
+}
+
+// s returns the bits of x as an Int128.
+func (x Uint128) s() Int128 {
+	return Int128{int64(x.Hi), x.Lo}
+}
+
+// abs returns the magnitude of x as a Uint128.
+// Unlike Abs, it is exact for the most negative value.
+func (x Int128) abs() Uint128 {
+	if x.Hi < 0 {
+		return Uint128{}.Sub(x.u())
+	}
+	return x.u()
+}
+
+// Add returns the sum x+y, wrapping around on overflow.
+func (x Int128) Add(y Int128) Int128 {
+	return x.u().Add(y.u()).s()
+}
+
+// Sub returns the difference x-y, wrapping around on overflow.
+func (x Int128) Sub(y Int128) Int128 {
+	return x.u().Sub(y.u()).s()
+}
+
+// Mul returns the least significant 128 bits of the product x*y.
+func (x Int128) Mul(y Int128) Int128 {
+	// The low half of a two's complement product does not
+	// depend on the signs of its operands.
+	return x.u().Mul(y.u()).s()
+}
+
+// QuoRem returns the quotient x/y and remainder x%y,
+// implementing truncated division like Go's / and % operators.
+// If x is the most negative value and y is -1, QuoRem returns (x, 0).
+// QuoRem panics if y is zero.
+func (x Int128) QuoRem(y Int128) (q, r Int128) {
+	qm, rm := x.abs().QuoRem(y.abs())
+	q, r = qm.s(), rm.s()
+	if (x.Hi < 0) != (y.Hi < 0) {
+		q = q.Neg()
+	}
+	if x.Hi < 0 {
+		r = r.Neg()
+	}
+	return
+}
+
+// Quo returns the quotient x/y, truncated toward zero.
+// If x is the most negative value and y is -1, Quo returns x.
+// Quo panics if y is zero.
+func (x Int128) Quo(y Int128) Int128 {
+	q, _ := x.QuoRem(y)
+	return q
+}
+
+// Rem returns the remainder x%y of truncated division.
+// The result has the sign of x.
+// Rem panics if y is zero.
+func (x Int128) Rem(y Int128) Int128 {
+	_, r := x.QuoRem(y)
+	return r
+}
+
+// DivMod returns the quotient x div y and modulus x mod y,
+// implementing Euclidean division: the modulus m always
+// satisfies 0 <= m < |y|.
+// If x is the most negative value and y is -1, DivMod returns (x, 0).
+// DivMod panics if y is zero.
+func (x Int128) DivMod(y Int128) (q, m Int128) {
+	q, m = x.QuoRem(y)
+	if m.Hi < 0 {
+		if y.Hi < 0 {
+			q = q.Add(Int128{0, 1})
+			m = m.Sub(y)
+		} else {
+			q = q.Sub(Int128{0, 1})
+			m = m.Add(y)
+		}
+	}
+	return
+}
+
+// Div returns the Euclidean quotient x div y.
+// If x is the most negative value and y is -1, Div returns x.
+// Div panics if y is zero.
+func (x Int128) Div(y Int128) Int128 {
+	q, _ := x.DivMod(y)
+	return q
+}
+
+// Mod returns the Euclidean modulus x mod y,
+// which is always in the range [0, |y|).
+// Mod panics if y is zero.
+func (x Int128) Mod(y Int128) Int128 {
+	_, m := x.DivMod(y)
+	return m
+}
+
+// Neg returns -x.
+// The negation of the most negative value is itself.
+func (x Int128) Neg() Int128 {
+	return Uint128{}.Sub(x.u()).s()
+}
+
+// Abs returns the absolute value |x|.
+// The absolute value of the most negative value is itself.
+func (x Int128) Abs() Int128 {
+	if x.Hi < 0 {
+		return x.Neg()
+	}
+	return x
+}
+
+// Sign returns:
+//
+//	-1 if x <  0
+//	 0 if x == 0
+//	+1 if x >  0
+func (x Int128) Sign() int {
+	switch {
+	case x.Hi < 0:
+		return -1
+	case x.Hi == 0 && x.Lo == 0:
+		return 0
+	}
+	return +1
+}
+
+// Cmp compares x and y and returns:
+//
+//	-1 if x <  y
+//	 0 if x == y
+//	+1 if x >  y
+func (x Int128) Cmp(y Int128) int {
+	switch {
+	case x.Hi < y.Hi, x.Hi == y.Hi && x.Lo < y.Lo:
+		return -1
+	case x == y:
+		return 0
+	}
+	return +1
+}
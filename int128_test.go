@@ -0,0 +1,110 @@
+// MIT License
+
+// Copyright (c) 2018 Akhil Indurti
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package extprec
+
+import (
+	"math/big"
+	"testing"
+)
+
+var minInt128 = Int128{-1 << 63, 0}
+
+func i128ToBig(x Int128) *big.Int {
+	z := u128ToBig(x.u())
+	if x.Hi < 0 {
+		z.Sub(z, two128)
+	}
+	return z
+}
+
+func bigToI128(z *big.Int) Int128 {
+	return bigToU128(new(big.Int).Mod(z, two128)).s()
+}
+
+func TestInt128Arith(t *testing.T) {
+	uvals := u128Values()
+	vals := make([]Int128, len(uvals))
+	for i, v := range uvals {
+		vals[i] = v.s()
+	}
+	for _, x := range vals {
+		bx := i128ToBig(x)
+		if got, want := x.Neg(), bigToI128(new(big.Int).Neg(bx)); got != want {
+			t.Errorf("%v.Neg() == %v; want %v", x, got, want)
+		}
+		if got, want := x.Abs(), bigToI128(new(big.Int).Abs(bx)); got != want {
+			t.Errorf("%v.Abs() == %v; want %v", x, got, want)
+		}
+		if got, want := x.Sign(), bx.Sign(); got != want {
+			t.Errorf("%v.Sign() == %d; want %d", x, got, want)
+		}
+		for _, y := range vals {
+			by := i128ToBig(y)
+			if got, want := x.Add(y), bigToI128(new(big.Int).Add(bx, by)); got != want {
+				t.Errorf("%v.Add(%v) == %v; want %v", x, y, got, want)
+			}
+			if got, want := x.Sub(y), bigToI128(new(big.Int).Sub(bx, by)); got != want {
+				t.Errorf("%v.Sub(%v) == %v; want %v", x, y, got, want)
+			}
+			if got, want := x.Mul(y), bigToI128(new(big.Int).Mul(bx, by)); got != want {
+				t.Errorf("%v.Mul(%v) == %v; want %v", x, y, got, want)
+			}
+			if got, want := x.Cmp(y), bx.Cmp(by); got != want {
+				t.Errorf("%v.Cmp(%v) == %d; want %d", x, y, got, want)
+			}
+			if by.Sign() == 0 {
+				continue
+			}
+			bq, br := new(big.Int).QuoRem(bx, by, new(big.Int))
+			if q, r := x.QuoRem(y); q != bigToI128(bq) || r != bigToI128(br) {
+				t.Errorf("%v.QuoRem(%v) == (%v, %v); want (%v, %v)", x, y, q, r, bigToI128(bq), bigToI128(br))
+			}
+			if q, r := x.Quo(y), x.Rem(y); q != bigToI128(bq) || r != bigToI128(br) {
+				t.Errorf("(%v.Quo(%v), %[1]v.Rem(%[2]v)) == (%v, %v); want (%v, %v)", x, y, q, r, bigToI128(bq), bigToI128(br))
+			}
+			bd, bm := new(big.Int).DivMod(bx, by, new(big.Int))
+			if q, m := x.DivMod(y); q != bigToI128(bd) || m != bigToI128(bm) {
+				t.Errorf("%v.DivMod(%v) == (%v, %v); want (%v, %v)", x, y, q, m, bigToI128(bd), bigToI128(bm))
+			}
+			if q, m := x.Div(y), x.Mod(y); q != bigToI128(bd) || m != bigToI128(bm) {
+				t.Errorf("(%v.Div(%v), %[1]v.Mod(%[2]v)) == (%v, %v); want (%v, %v)", x, y, q, m, bigToI128(bd), bigToI128(bm))
+			}
+		}
+	}
+}
+
+func TestInt128Min(t *testing.T) {
+	negOne := Int128{-1, 1<<64 - 1}
+	if got := minInt128.Neg(); got != minInt128 {
+		t.Errorf("MinInt128.Neg() == %v; want %v", got, minInt128)
+	}
+	if got := minInt128.Abs(); got != minInt128 {
+		t.Errorf("MinInt128.Abs() == %v; want %v", got, minInt128)
+	}
+	if q, r := minInt128.QuoRem(negOne); q != minInt128 || r != (Int128{}) {
+		t.Errorf("MinInt128.QuoRem(-1) == (%v, %v); want (%v, 0)", q, r, minInt128)
+	}
+	if q, m := minInt128.DivMod(negOne); q != minInt128 || m != (Int128{}) {
+		t.Errorf("MinInt128.DivMod(-1) == (%v, %v); want (%v, 0)", q, m, minInt128)
+	}
+}
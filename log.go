@@ -0,0 +1,147 @@
+// MIT License
+
+// Copyright (c) 2018 Akhil Indurti
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package extprec
+
+import . "math/bits"
+
+// Pow10_64 holds the powers of ten that fit in a uint64,
+// with Pow10_64[i] == 10^i.
+// It is a copy of the table used by this package, so
+// modifying it does not affect the functions below.
+var Pow10_64 = pow10_64
+
+// Pow10_128 holds the powers of ten that fit in 128 bits,
+// with Pow10_128[i] == 10^i.
+// It is a copy of the table used by this package, so
+// modifying it does not affect the functions below.
+var Pow10_128 = pow10_128
+
+var pow10_64 = [20]uint64{
+	1,
+	10,
+	100,
+	1000,
+	10000,
+	100000,
+	1000000,
+	10000000,
+	100000000,
+	1000000000,
+	10000000000,
+	100000000000,
+	1000000000000,
+	10000000000000,
+	100000000000000,
+	1000000000000000,
+	10000000000000000,
+	100000000000000000,
+	1000000000000000000,
+	10000000000000000000,
+}
+
+var pow10_128 = [39]Uint128{
+	{0x0, 0x1},                              // 10^0
+	{0x0, 0xA},                              // 10^1
+	{0x0, 0x64},                             // 10^2
+	{0x0, 0x3E8},                            // 10^3
+	{0x0, 0x2710},                           // 10^4
+	{0x0, 0x186A0},                          // 10^5
+	{0x0, 0xF4240},                          // 10^6
+	{0x0, 0x989680},                         // 10^7
+	{0x0, 0x5F5E100},                        // 10^8
+	{0x0, 0x3B9ACA00},                       // 10^9
+	{0x0, 0x2540BE400},                      // 10^10
+	{0x0, 0x174876E800},                     // 10^11
+	{0x0, 0xE8D4A51000},                     // 10^12
+	{0x0, 0x9184E72A000},                    // 10^13
+	{0x0, 0x5AF3107A4000},                   // 10^14
+	{0x0, 0x38D7EA4C68000},                  // 10^15
+	{0x0, 0x2386F26FC10000},                 // 10^16
+	{0x0, 0x16345785D8A0000},                // 10^17
+	{0x0, 0xDE0B6B3A7640000},                // 10^18
+	{0x0, 0x8AC7230489E80000},               // 10^19
+	{0x5, 0x6BC75E2D63100000},               // 10^20
+	{0x36, 0x35C9ADC5DEA00000},              // 10^21
+	{0x21E, 0x19E0C9BAB2400000},             // 10^22
+	{0x152D, 0x2C7E14AF6800000},             // 10^23
+	{0xD3C2, 0x1BCECCEDA1000000},            // 10^24
+	{0x84595, 0x161401484A000000},           // 10^25
+	{0x52B7D2, 0xDCC80CD2E4000000},          // 10^26
+	{0x33B2E3C, 0x9FD0803CE8000000},         // 10^27
+	{0x204FCE5E, 0x3E25026110000000},        // 10^28
+	{0x1431E0FAE, 0x6D7217CAA0000000},       // 10^29
+	{0xC9F2C9CD0, 0x4674EDEA40000000},       // 10^30
+	{0x7E37BE2022, 0xC0914B2680000000},      // 10^31
+	{0x4EE2D6D415B, 0x85ACEF8100000000},     // 10^32
+	{0x314DC6448D93, 0x38C15B0A00000000},    // 10^33
+	{0x1ED09BEAD87C0, 0x378D8E6400000000},   // 10^34
+	{0x13426172C74D82, 0x2B878FE800000000},  // 10^35
+	{0xC097CE7BC90715, 0xB34B9F1000000000},  // 10^36
+	{0x785EE10D5DA46D9, 0xF436A000000000},   // 10^37
+	{0x4B3B4CA85A86C47A, 0x98A224000000000}, // 10^38
+}
+
+// Log2_128 returns floor(log2(hi || lo)),
+// or -1 if (hi || lo) is zero.
+func Log2_128(hi, lo uint64) int {
+	if hi != 0 {
+		return 64 + Len64(hi) - 1
+	}
+	return Len64(lo) - 1
+}
+
+// Log10_64 returns floor(log10(x)), or -1 if x is zero.
+func Log10_64(x uint64) int {
+	// See "Hacker's Delight", Ch. 11-4: Integer Logarithm
+	//
+	// 1233/4096 approximates log10(2) closely enough that the
+	// estimate from the bit length is exact or one too large.
+	t := Len64(x) * 1233 >> 12
+	if x < pow10_64[t] {
+		t--
+	}
+	return t
+}
+
+// Log10_128 returns floor(log10(hi || lo)),
+// or -1 if (hi || lo) is zero.
+func Log10_128(hi, lo uint64) int {
+	// See "Hacker's Delight", Ch. 11-4: Integer Logarithm
+	if hi == 0 {
+		return Log10_64(lo)
+	}
+	t := (Log2_128(hi, lo) + 1) * 1233 >> 12
+	if (Uint128{hi, lo}).Cmp(pow10_128[t]) < 0 {
+		t--
+	}
+	return t
+}
+
+// IsPow10 reports whether (hi || lo) is a power of ten.
+func IsPow10(hi, lo uint64) bool {
+	if t := Log10_128(hi, lo); t >= 0 {
+		p := pow10_128[t]
+		return p.Hi == hi && p.Lo == lo
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+// MIT License
+
+// Copyright (c) 2018 Akhil Indurti
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package extprec
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestPow10Tables(t *testing.T) {
+	p := big.NewInt(1)
+	for i := range Pow10_128 {
+		if got := u128ToBig(Pow10_128[i]); got.Cmp(p) != 0 {
+			t.Errorf("Pow10_128[%d] == %v; want %v", i, got, p)
+		}
+		if i < len(Pow10_64) && Pow10_64[i] != p.Uint64() {
+			t.Errorf("Pow10_64[%d] == %d; want %v", i, Pow10_64[i], p)
+		}
+		p.Mul(p, big.NewInt(10))
+	}
+	if p.Cmp(two128) <= 0 {
+		t.Errorf("Pow10_128 is missing 10^%d", len(Pow10_128))
+	}
+
+	// The exported tables are copies; clobbering them must not
+	// change the results of the functions that use the tables.
+	save64, save128 := Pow10_64, Pow10_128
+	defer func() { Pow10_64, Pow10_128 = save64, save128 }()
+	Pow10_64, Pow10_128 = [20]uint64{}, [39]Uint128{}
+	if got := Log10_64(999); got != 2 {
+		t.Errorf("Log10_64(999) == %d after modifying Pow10_64; want 2", got)
+	}
+	if got := Log10_128(0, 999); got != 2 {
+		t.Errorf("Log10_128(0, 999) == %d after modifying Pow10_128; want 2", got)
+	}
+	if !IsPow10(save128[30].Hi, save128[30].Lo) {
+		t.Errorf("IsPow10(10^30) == false after modifying Pow10_128")
+	}
+}
+
+func TestLog(t *testing.T) {
+	r := rand.New(rand.NewSource(29))
+	x := u128Values()
+	for _, p := range Pow10_128 {
+		x = append(x, p, p.Sub(Uint128{0, 1}), p.Add(Uint128{0, 1}), p.mul64(2))
+	}
+	for i := uint(0); i < 128; i++ {
+		q := Uint128{0, 1}.Lsh(i)
+		x = append(x, q, q.Sub(Uint128{0, 1}), Uint128{r.Uint64(), r.Uint64()}.Rsh(i))
+	}
+	ten := big.NewInt(10)
+	for _, v := range x {
+		b := u128ToBig(v)
+		want2, want10, pow10 := b.BitLen()-1, -1, false
+		for p := big.NewInt(1); p.Cmp(b) <= 0; p.Mul(p, ten) {
+			want10++
+			pow10 = p.Cmp(b) == 0
+		}
+		if got := Log2_128(v.Hi, v.Lo); got != want2 {
+			t.Errorf("Log2_128(%v) == %d; want %d", v, got, want2)
+		}
+		if got := Log10_128(v.Hi, v.Lo); got != want10 {
+			t.Errorf("Log10_128(%v) == %d; want %d", v, got, want10)
+		}
+		if got := IsPow10(v.Hi, v.Lo); got != pow10 {
+			t.Errorf("IsPow10(%v) == %v; want %v", v, got, pow10)
+		}
+		if v.Hi == 0 {
+			if got := Log10_64(v.Lo); got != want10 {
+				t.Errorf("Log10_64(%d) == %d; want %d", v.Lo, got, want10)
+			}
+		}
+	}
+}
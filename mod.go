@@ -0,0 +1,174 @@
+// MIT License
+
+// Copyright (c) 2018 Akhil Indurti
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package extprec
+
+// AddMod32 returns (x + y) mod m, for x and y less than m.
+func AddMod32(x, y, m uint32) uint32 {
+	sum, c := Add32(x, y, 0)
+	if c != 0 || sum >= m {
+		sum -= m
+	}
+	return sum
+}
+
+// AddMod64 returns (x + y) mod m, for x and y less than m.
+func AddMod64(x, y, m uint64) uint64 {
+	// The sum is less than 2m, so at most one subtraction
+	// is needed, even if the sum overflowed.
+	sum, c := Add64(x, y, 0)
+	if c != 0 || sum >= m {
+		sum -= m
+	}
+	return sum
+}
+
+// SubMod32 returns (x - y) mod m, for x and y less than m.
+func SubMod32(x, y, m uint32) uint32 {
+	difference, b := Sub32(x, y, 0)
+	if b != 0 {
+		difference += m
+	}
+	return difference
+}
+
+// SubMod64 returns (x - y) mod m, for x and y less than m.
+func SubMod64(x, y, m uint64) uint64 {
+	difference, b := Sub64(x, y, 0)
+	if b != 0 {
+		difference += m
+	}
+	return difference
+}
+
+// MulMod32 returns (x * y) mod m, for x and y less than m.
+// MulMod32 panics with ErrDivideByZero if m is zero.
+func MulMod32(x, y, m uint32) uint32 {
+	hi, lo := Mul32(x, y)
+	_, rem := Div32(hi, lo, m)
+	return rem
+}
+
+// MulMod64 returns (x * y) mod m, for x and y less than m.
+// MulMod64 panics with ErrDivideByZero if m is zero.
+func MulMod64(x, y, m uint64) uint64 {
+	// Since x < m, the high word of the product is less than m.
+	hi, lo := Mul64(x, y)
+	_, rem := Div64(hi, lo, m)
+	return rem
+}
+
+// ExpMod32 returns x**e mod m, for x less than m.
+// ExpMod32 panics with ErrDivideByZero if m is zero.
+func ExpMod32(x, e, m uint32) uint32 {
+	if m == 0 {
+		panic(ErrDivideByZero)
+	}
+	// See ExpMod64.
+	z := 1 % m
+	for ; e != 0; e >>= 1 {
+		if e&1 != 0 {
+			z = MulMod32(z, x, m)
+		}
+		x = MulMod32(x, x, m)
+	}
+	return z
+}
+
+// ExpMod64 returns x**e mod m, for x less than m.
+// ExpMod64 panics with ErrDivideByZero if m is zero.
+func ExpMod64(x, e, m uint64) uint64 {
+	if m == 0 {
+		panic(ErrDivideByZero)
+	}
+	// Square and multiply, from the least significant bit of e.
+	z := 1 % m
+	for ; e != 0; e >>= 1 {
+		if e&1 != 0 {
+			z = MulMod64(z, x, m)
+		}
+		x = MulMod64(x, x, m)
+	}
+	return z
+}
+
+// Inverse64 returns the inverse of a modulo 2^64, that is, the x
+// for which a*x == 1 when multiplied as uint64 values.
+// Only odd numbers have an inverse; for even a, the result is meaningless.
+func Inverse64(a uint64) uint64 {
+	// See "Hacker's Delight", Ch. 10-16: Exact Division by Constants
+	//
+	// a*a == 1 mod 8, so a is its own inverse to 3 bits.
+	// Each Newton step x = x*(2 - a*x) doubles the number of
+	// correct bits: 3, 6, 12, 24, 48, 96.
+	x := a
+	for i := 0; i < 5; i++ {
+		x *= 2 - a*x
+	}
+	return x
+}
+
+// ExtGCD64 returns the greatest common divisor g of a and b,
+// along with Bézout coefficients x and y such that a*x + b*y == g.
+// The coefficients are those found by the extended Euclidean algorithm,
+// which are at most about half of b/g and a/g in magnitude, and so
+// always fit in an int64. ExtGCD64(a, 0) returns (a, 1, 0),
+// and ExtGCD64(0, b) returns (b, 0, 1) for b != 0.
+func ExtGCD64(a, b uint64) (g uint64, x, y int64) {
+	// See Knuth, "The Art of Computer Programming", Vol. 2, Section 4.5.2,
+	// Algorithm X.
+	//
+	// Maintain r0 == a*x0 + b*y0 and r1 == a*x1 + b*y1 while
+	// running Euclid's algorithm on (r0, r1). The coefficients
+	// are only bounded until the final step, which computes
+	// (x1, y1) for r1 == 0; those values are discarded, so it
+	// does not matter that they may wrap around.
+	r0, r1 := a, b
+	x0, x1 := int64(1), int64(0)
+	y0, y1 := int64(0), int64(1)
+	for r1 != 0 {
+		q := r0 / r1
+		r0, r1 = r1, r0-q*r1
+		x0, x1 = x1, x0-int64(q)*x1
+		y0, y1 = y1, y0-int64(q)*y1
+	}
+	return r0, x0, y0
+}
+
+// InvMod64 returns the inverse of a modulo m, that is, the x less
+// than m for which a*x mod m == 1. If a and m are not coprime,
+// a has no inverse, and InvMod64 returns (0, false).
+func InvMod64(a, m uint64) (inv uint64, ok bool) {
+	if m == 0 {
+		return 0, false
+	}
+	g, x, _ := ExtGCD64(a%m, m)
+	if g != 1 {
+		return 0, false
+	}
+	// |x| < m, so a negative x needs one addition of m.
+	inv = uint64(x)
+	if x < 0 {
+		inv += m
+	}
+	return inv, true
+}
@@ -0,0 +1,156 @@
+// MIT License
+
+// Copyright (c) 2018 Akhil Indurti
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package extprec
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestMod64(t *testing.T) {
+	r := rand.New(rand.NewSource(19))
+	for _, m := range moduli64(r, 200) {
+		bm := new(big.Int).SetUint64(m)
+		xs := residues(r, m, 10)
+		for _, x := range xs {
+			for _, y := range xs {
+				bx, by := new(big.Int).SetUint64(x), new(big.Int).SetUint64(y)
+				want := new(big.Int)
+				if got := AddMod64(x, y, m); got != want.Mod(want.Add(bx, by), bm).Uint64() {
+					t.Errorf("AddMod64(%d, %d, %d) == %d; want %d", x, y, m, got, want)
+				}
+				if got := SubMod64(x, y, m); got != want.Mod(want.Sub(bx, by), bm).Uint64() {
+					t.Errorf("SubMod64(%d, %d, %d) == %d; want %d", x, y, m, got, want)
+				}
+				if got := MulMod64(x, y, m); got != want.Mod(want.Mul(bx, by), bm).Uint64() {
+					t.Errorf("MulMod64(%d, %d, %d) == %d; want %d", x, y, m, got, want)
+				}
+				if got := ExpMod64(x, y, m); got != want.Exp(bx, by, bm).Uint64() {
+					t.Errorf("ExpMod64(%d, %d, %d) == %d; want %d", x, y, m, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestMod32(t *testing.T) {
+	r := rand.New(rand.NewSource(20))
+	for _, m64 := range moduli64(r, 200) {
+		m := uint32(m64)
+		if m == 0 {
+			continue
+		}
+		for _, x64 := range residues(r, uint64(m), 10) {
+			for _, y64 := range residues(r, uint64(m), 10) {
+				x, y := uint32(x64), uint32(y64)
+				if got, want := AddMod32(x, y, m), uint32((x64+y64)%uint64(m)); got != want {
+					t.Errorf("AddMod32(%d, %d, %d) == %d; want %d", x, y, m, got, want)
+				}
+				if got, want := SubMod32(x, y, m), uint32((x64+uint64(m)-y64)%uint64(m)); got != want {
+					t.Errorf("SubMod32(%d, %d, %d) == %d; want %d", x, y, m, got, want)
+				}
+				if got, want := MulMod32(x, y, m), uint32(x64*y64%uint64(m)); got != want {
+					t.Errorf("MulMod32(%d, %d, %d) == %d; want %d", x, y, m, got, want)
+				}
+				if got, want := ExpMod32(x, y, m), uint32(ExpMod64(x64, y64, uint64(m))); got != want {
+					t.Errorf("ExpMod32(%d, %d, %d) == %d; want %d", x, y, m, got, want)
+				}
+			}
+		}
+	}
+	if err := divPanic(func() { ExpMod32(0, 0, 0) }); err != ErrDivideByZero {
+		t.Errorf("ExpMod32(0, 0, 0) panicked with %v; want %v", err, ErrDivideByZero)
+	}
+	if err := divPanic(func() { ExpMod64(0, 0, 0) }); err != ErrDivideByZero {
+		t.Errorf("ExpMod64(0, 0, 0) panicked with %v; want %v", err, ErrDivideByZero)
+	}
+}
+
+func TestInverse64(t *testing.T) {
+	r := rand.New(rand.NewSource(21))
+	for _, a := range moduli64(r, 1000) {
+		a |= 1
+		if got := a * Inverse64(a); got != 1 {
+			t.Errorf("%d * Inverse64(%d) == %d; want 1", a, a, got)
+		}
+	}
+}
+
+func TestExtGCD64(t *testing.T) {
+	r := rand.New(rand.NewSource(22))
+	vals := append([]uint64{0}, moduli64(r, 100)...)
+	for _, a := range vals {
+		for _, b := range vals {
+			g, x, y := ExtGCD64(a, b)
+			ba, bb := new(big.Int).SetUint64(a), new(big.Int).SetUint64(b)
+			want := new(big.Int).GCD(nil, nil, ba, bb)
+			if g != want.Uint64() {
+				t.Errorf("ExtGCD64(%d, %d) == (%d, %d, %d); want gcd %d", a, b, g, x, y, want)
+				continue
+			}
+			// Check the Bézout identity without overflow.
+			bz := new(big.Int).Mul(ba, big.NewInt(x))
+			bz.Add(bz, new(big.Int).Mul(bb, big.NewInt(y)))
+			if bz.Cmp(want) != 0 {
+				t.Errorf("ExtGCD64(%d, %d) == (%d, %d, %d); %d*%d + %d*%d == %d", a, b, g, x, y, a, x, b, y, bz)
+			}
+		}
+	}
+	for _, c := range []struct {
+		a, b, g uint64
+		x, y    int64
+	}{
+		{0, 0, 0, 1, 0},
+		{7, 0, 7, 1, 0},
+		{0, 7, 7, 0, 1},
+	} {
+		if g, x, y := ExtGCD64(c.a, c.b); g != c.g || x != c.x || y != c.y {
+			t.Errorf("ExtGCD64(%d, %d) == (%d, %d, %d); want (%d, %d, %d)", c.a, c.b, g, x, y, c.g, c.x, c.y)
+		}
+	}
+}
+
+func TestInvMod64(t *testing.T) {
+	r := rand.New(rand.NewSource(23))
+	for _, m := range moduli64(r, 100) {
+		bm := new(big.Int).SetUint64(m)
+		for _, a := range residues(r, m, 20) {
+			inv, ok := InvMod64(a, m)
+			want := new(big.Int).ModInverse(new(big.Int).SetUint64(a), bm)
+			if m == 1 {
+				// Every value is congruent to its inverse, 0.
+				want = new(big.Int)
+			}
+			switch {
+			case want == nil && ok:
+				t.Errorf("InvMod64(%d, %d) == (%d, true); want (0, false)", a, m, inv)
+			case want != nil && (!ok || inv != want.Uint64()):
+				t.Errorf("InvMod64(%d, %d) == (%d, %t); want (%d, true)", a, m, inv, ok, want)
+			}
+		}
+	}
+	if inv, ok := InvMod64(1, 0); ok {
+		t.Errorf("InvMod64(1, 0) == (%d, true); want (0, false)", inv)
+	}
+}
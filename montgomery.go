@@ -0,0 +1,133 @@
+// MIT License
+
+// Copyright (c) 2018 Akhil Indurti
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package extprec
+
+// A Montgomery64 performs modular arithmetic for a fixed odd 64-bit
+// modulus m in Montgomery form, where a value x is represented by
+// x*R mod m with R = 2^64. Multiplying in Montgomery form replaces
+// the division by m with multiplications and a shift by R.
+//
+// Mul, Add, Sub and Exp take and return values in Montgomery form,
+// which must be less than m. ToMont and FromMont convert to and from it.
+type Montgomery64 struct {
+	m    uint64 // modulus
+	mInv uint64 // -m^-1 mod R
+	r1   uint64 // R mod m, the Montgomery form of 1
+	r2   uint64 // R^2 mod m
+}
+
+// argError is the type of the panics raised by constructors given
+// an invalid argument. Unlike divError, it is not a run-time error.
+type argError string
+
+func (e argError) Error() string { return string(e) }
+
+// ErrEvenModulus is raised as a panic when
+// NewMontgomery64 is given an even modulus.
+var ErrEvenModulus error = argError("extprec: even Montgomery modulus")
+
+// NewMontgomery64 returns a Montgomery64 for the odd modulus m.
+// NewMontgomery64 panics with ErrEvenModulus if m is even.
+func NewMontgomery64(m uint64) Montgomery64 {
+	if m&1 == 0 {
+		panic(ErrEvenModulus)
+	}
+	r1 := Rem64(1, 0, m)
+	hi, lo := Mul64(r1, r1)
+	return Montgomery64{m: m, mInv: -Inverse64(m), r1: r1, r2: Rem64(hi, lo, m)}
+}
+
+// Modulus returns the modulus m.
+func (mg Montgomery64) Modulus() uint64 {
+	return mg.m
+}
+
+// reduce returns (hi || lo) * R^-1 mod m, for (hi || lo) < m*R.
+func (mg Montgomery64) reduce(hi, lo uint64) uint64 {
+	// See Montgomery, "Modular multiplication without trial division",
+	// Mathematics of Computation 44 (1985).
+	//
+	// Adding u*m, where u = lo * -m^-1 mod R, clears the low word
+	// without changing the value modulo m. The high word is then
+	// less than 2m, and needs at most one subtraction of m.
+
+	u := lo * mg.mInv
+	th, tl := Mul64(u, mg.m)
+	_, c := Add64(lo, tl, 0)
+	t, c := Add64(hi, th, c)
+	if c != 0 || t >= mg.m {
+		t -= mg.m
+	}
+	return t
+}
+
+// ToMont returns the Montgomery form x*R mod m of x.
+func (mg Montgomery64) ToMont(x uint64) uint64 {
+	return mg.Mul(x%mg.m, mg.r2)
+}
+
+// FromMont returns the value x*R^-1 mod m represented by
+// the Montgomery form x.
+func (mg Montgomery64) FromMont(x uint64) uint64 {
+	return mg.reduce(0, x)
+}
+
+// Mul returns the Montgomery form of the product of
+// the values represented by x and y.
+func (mg Montgomery64) Mul(x, y uint64) uint64 {
+	return mg.reduce(Mul64(x, y))
+}
+
+// Add returns the Montgomery form of the sum of
+// the values represented by x and y.
+func (mg Montgomery64) Add(x, y uint64) uint64 {
+	sum, c := Add64(x, y, 0)
+	if c != 0 || sum >= mg.m {
+		sum -= mg.m
+	}
+	return sum
+}
+
+// Sub returns the Montgomery form of the difference of
+// the values represented by x and y.
+func (mg Montgomery64) Sub(x, y uint64) uint64 {
+	difference, b := Sub64(x, y, 0)
+	if b != 0 {
+		difference += mg.m
+	}
+	return difference
+}
+
+// Exp returns the Montgomery form of the value represented
+// by x raised to the power e.
+func (mg Montgomery64) Exp(x, e uint64) uint64 {
+	// Square and multiply, from the least significant bit of e.
+	z := mg.r1
+	for ; e != 0; e >>= 1 {
+		if e&1 != 0 {
+			z = mg.Mul(z, x)
+		}
+		x = mg.Mul(x, x)
+	}
+	return z
+}
@@ -0,0 +1,102 @@
+// MIT License
+
+// Copyright (c) 2018 Akhil Indurti
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package extprec
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// moduli64 returns small moduli, moduli near powers of two,
+// and n pseudo-random moduli, all greater than zero.
+func moduli64(r *rand.Rand, n int) []uint64 {
+	m := []uint64{1, 2, 3, 4, 5, 7, 10, 97, 1<<32 - 5, 1<<32 + 15, 1<<61 - 1, 1<<63 - 25, 1 << 63, 1<<63 + 1, 1<<64 - 59, 1<<64 - 1}
+	for want := len(m) + n; len(m) < want; {
+		if x := r.Uint64() >> uint(r.Intn(64)); x != 0 {
+			m = append(m, x)
+		}
+	}
+	return m
+}
+
+// residues returns values below m, including its extremes.
+func residues(r *rand.Rand, m uint64, n int) []uint64 {
+	x := []uint64{0, 1 % m, m - 1, m / 2}
+	for i := 0; i < n; i++ {
+		x = append(x, r.Uint64()%m)
+	}
+	return x
+}
+
+func TestMontgomery64(t *testing.T) {
+	r := rand.New(rand.NewSource(17))
+	for _, m := range moduli64(r, 200) {
+		if m&1 == 0 {
+			continue
+		}
+		mg := NewMontgomery64(m)
+		if mg.Modulus() != m {
+			t.Errorf("NewMontgomery64(%d).Modulus() == %d", m, mg.Modulus())
+		}
+		bm := new(big.Int).SetUint64(m)
+		xs := residues(r, m, 10)
+		for _, x := range xs {
+			mx := mg.ToMont(x)
+			if got := mg.FromMont(mx); got != x {
+				t.Errorf("FromMont(ToMont(%d)) == %d mod %d", x, got, m)
+			}
+			for _, y := range xs {
+				my := mg.ToMont(y)
+				bx, by := new(big.Int).SetUint64(x), new(big.Int).SetUint64(y)
+				want := new(big.Int).Mul(bx, by)
+				if got := mg.FromMont(mg.Mul(mx, my)); got != want.Mod(want, bm).Uint64() {
+					t.Errorf("%d*%d == %d mod %d; want %d", x, y, got, m, want)
+				}
+				want.Add(bx, by)
+				if got := mg.FromMont(mg.Add(mx, my)); got != want.Mod(want, bm).Uint64() {
+					t.Errorf("%d+%d == %d mod %d; want %d", x, y, got, m, want)
+				}
+				want.Sub(bx, by)
+				if got := mg.FromMont(mg.Sub(mx, my)); got != want.Mod(want, bm).Uint64() {
+					t.Errorf("%d-%d == %d mod %d; want %d", x, y, got, m, want)
+				}
+				want.Exp(bx, by, bm)
+				if m == 1 {
+					want.SetUint64(0)
+				}
+				if got := mg.FromMont(mg.Exp(mx, y)); got != want.Uint64() {
+					t.Errorf("%d**%d == %d mod %d; want %d", x, y, got, m, want)
+				}
+			}
+		}
+	}
+	func() {
+		defer func() {
+			if err := recover(); err != ErrEvenModulus {
+				t.Errorf("NewMontgomery64(10) panicked with %v; want %v", err, ErrEvenModulus)
+			}
+		}()
+		NewMontgomery64(10)
+	}()
+}
@@ -0,0 +1,98 @@
+// MIT License
+
+// Copyright (c) 2018 Akhil Indurti
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package extprec
+
+import . "math/bits"
+
+// primeBases are the Miller–Rabin bases that correctly decide primality
+// for all n < 3.18 * 10^23, and so for every uint64. They are also used
+// for trial division before the Miller–Rabin test.
+var primeBases = [...]uint64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37}
+
+// IsPrime64 reports whether n is prime.
+func IsPrime64(n uint64) bool {
+	// See Sorenson and Webster, "Strong pseudoprimes to twelve prime
+	// bases", Math. Comp. 86 (2017), for the deterministic base set.
+	for _, p := range primeBases {
+		if n%p == 0 {
+			return n == p
+		}
+	}
+	if n < 41*41 {
+		// No prime factor is less than 41.
+		return n > 1
+	}
+
+	// Write n-1 = d * 2^s with d odd, and check that each base a
+	// satisfies a^d == 1 or a^(d*2^r) == -1 (mod n) for some r < s.
+	s := uint(TrailingZeros64(n - 1))
+	d := (n - 1) >> s
+	mg := NewMontgomery64(n)
+	one := mg.ToMont(1)
+	minusOne := mg.Sub(0, one)
+next:
+	for _, a := range primeBases {
+		x := mg.Exp(mg.ToMont(a), d)
+		if x == one || x == minusOne {
+			continue
+		}
+		for r := uint(1); r < s; r++ {
+			x = mg.Mul(x, x)
+			if x == minusOne {
+				continue next
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// NextPrime64 returns the smallest prime greater than n,
+// or 0 if there is none less than 2^64.
+func NextPrime64(n uint64) uint64 {
+	if n < 2 {
+		return 2
+	}
+	for p := (n + 1) | 1; p > n; p += 2 {
+		if IsPrime64(p) {
+			return p
+		}
+	}
+	return 0
+}
+
+// PrevPrime64 returns the largest prime less than n,
+// or 0 if n <= 2.
+func PrevPrime64(n uint64) uint64 {
+	switch {
+	case n <= 2:
+		return 0
+	case n == 3:
+		return 2
+	}
+	p := (n - 2) | 1
+	for !IsPrime64(p) {
+		p -= 2
+	}
+	return p
+}
@@ -0,0 +1,90 @@
+// MIT License
+
+// Copyright (c) 2018 Akhil Indurti
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package extprec
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// strongPseudoprimes are composites that pass the Miller–Rabin test for
+// several of the smallest prime bases, along with Carmichael numbers.
+var strongPseudoprimes = []uint64{
+	561, 1105, 1729, 2047, 1373653, 25326001, 3215031751, 2152302898747,
+	3474749660383, 341550071728321, 3825123056546413051,
+	// Products of two primes close to 2^32.
+	4294967291 * 4294967279, 4294967291 * 4294967291,
+}
+
+func TestIsPrime64(t *testing.T) {
+	r := rand.New(rand.NewSource(22))
+	n := append([]uint64{1<<61 - 1, 1<<64 - 59, 1<<64 - 1}, strongPseudoprimes...)
+	for i := uint64(0); i < 5000; i++ {
+		n = append(n, i, 1<<64-1-i)
+	}
+	n = append(n, moduli64(r, 10000)...)
+	for _, x := range n {
+		want := new(big.Int).SetUint64(x).ProbablyPrime(0)
+		if got := IsPrime64(x); got != want {
+			t.Errorf("IsPrime64(%d) == %v; want %v", x, got, want)
+		}
+	}
+}
+
+func TestNextPrevPrime64(t *testing.T) {
+	r := rand.New(rand.NewSource(23))
+	tests := []struct {
+		n, next, prev uint64
+	}{
+		{0, 2, 0},
+		{1, 2, 0},
+		{2, 3, 0},
+		{3, 5, 2},
+		{4, 5, 3},
+		{5, 7, 3},
+		{1<<32 - 5, 1<<32 + 15, 1<<32 - 17},
+		{1<<64 - 59, 0, 1<<64 - 83},
+		{1<<64 - 1, 0, 1<<64 - 59},
+	}
+	for _, tt := range tests {
+		if got := NextPrime64(tt.n); got != tt.next {
+			t.Errorf("NextPrime64(%d) == %d; want %d", tt.n, got, tt.next)
+		}
+		if got := PrevPrime64(tt.n); got != tt.prev {
+			t.Errorf("PrevPrime64(%d) == %d; want %d", tt.n, got, tt.prev)
+		}
+	}
+	for _, n := range moduli64(r, 500) {
+		if p := NextPrime64(n); p != 0 {
+			if p <= n || !IsPrime64(p) || PrevPrime64(p) > n {
+				t.Errorf("NextPrime64(%d) == %d", n, p)
+			}
+		}
+		if p := PrevPrime64(n); p != 0 {
+			if q := NextPrime64(p); p >= n || !IsPrime64(p) || q != 0 && q < n {
+				t.Errorf("PrevPrime64(%d) == %d", n, p)
+			}
+		}
+	}
+}
@@ -0,0 +1,286 @@
+// MIT License
+
+// Copyright (c) 2018 Akhil Indurti
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package extprec
+
+import . "math/bits"
+
+// A Reciprocal64 is a precomputed reciprocal of a 64-bit divisor.
+// Dividing by a Reciprocal64 replaces the long division in Div64
+// with a few multiplications, which pays off when many dividends
+// are divided by the same divisor.
+type Reciprocal64 struct {
+	d uint64 // divisor, normalized so that its most significant bit is set
+	v uint64 // reciprocal of d
+	s uint   // normalization shift
+}
+
+// NewReciprocal64 returns the reciprocal of x.
+// NewReciprocal64 panics with ErrDivideByZero if x is zero.
+func NewReciprocal64(x uint64) Reciprocal64 {
+	if x == 0 {
+		panic(ErrDivideByZero)
+	}
+	s := uint(LeadingZeros64(x))
+	d := x << s
+	return Reciprocal64{d: d, v: reciprocal64(d), s: s}
+}
+
+// reciprocal64 returns floor((2^128-1)/d) - 2^64
+// for a normalized divisor d.
+func reciprocal64(d uint64) uint64 {
+	// 2^128-1 - 2^64*d = (^d || 2^64-1), and ^d < d.
+	v, _ := Div64(^d, 1<<64-1, d)
+	return v
+}
+
+// Divisor returns the divisor x that r was created from.
+func (r Reciprocal64) Divisor() uint64 {
+	return r.d >> r.s
+}
+
+// DivRem returns the 64-bit quotient and remainder of (hi || lo)
+// and the divisor, where hi and lo hold the most significant and
+// least significant 64 bits of the dividend.
+// DivRem panics with ErrQuotientOverflow if hi >= the divisor.
+func (r Reciprocal64) DivRem(hi, lo uint64) (quo, rem uint64) {
+	if hi >= r.d>>r.s {
+		panic(ErrQuotientOverflow)
+	}
+	u1 := hi<<r.s | lo>>(64-r.s)
+	u0 := lo << r.s
+	quo, rem = divRem2by1_64(u1, u0, r.d, r.v)
+	rem >>= r.s
+	return
+}
+
+// divRem2by1_64 returns the quotient and remainder of (u1 || u0)
+// and the normalized divisor d, where v is the reciprocal of d
+// and u1 < d.
+func divRem2by1_64(u1, u0, d, v uint64) (q, r uint64) {
+	// See Möller and Granlund, "Improved division by invariant integers",
+	// IEEE Transactions on Computers 60 (2011), Algorithm 4.
+	//
+	// Estimate the quotient as the high word of v*u1 + (u1+1 || u0).
+	// The estimate is at most one too large, or one too small, and the
+	// candidate remainder tells which correction to apply.
+
+	q, q0 := Mul64(v, u1)
+	var c uint64
+	q0, c = Add64(q0, u0, 0)
+	q, _ = Add64(q, u1+1, c)
+	r = u0 - q*d
+	if r > q0 {
+		q--
+		r += d
+	}
+	if r >= d {
+		q++
+		r -= d
+	}
+	return
+}
+
+// A Reciprocal32 is a precomputed reciprocal of a 32-bit divisor.
+// Dividing by a Reciprocal32 replaces the division in Div32
+// with a few multiplications, which pays off when many dividends
+// are divided by the same divisor.
+type Reciprocal32 struct {
+	d uint32 // divisor, normalized so that its most significant bit is set
+	v uint32 // reciprocal of d
+	s uint   // normalization shift
+}
+
+// NewReciprocal32 returns the reciprocal of x.
+// NewReciprocal32 panics with ErrDivideByZero if x is zero.
+func NewReciprocal32(x uint32) Reciprocal32 {
+	if x == 0 {
+		panic(ErrDivideByZero)
+	}
+	s := uint(LeadingZeros32(x))
+	d := x << s
+	v, _ := Div32(^d, 1<<32-1, d)
+	return Reciprocal32{d: d, v: v, s: s}
+}
+
+// Divisor returns the divisor x that r was created from.
+func (r Reciprocal32) Divisor() uint32 {
+	return r.d >> r.s
+}
+
+// DivRem returns the 32-bit quotient and remainder of (hi || lo)
+// and the divisor, where hi and lo hold the most significant and
+// least significant 32 bits of the dividend.
+// DivRem panics with ErrQuotientOverflow if hi >= the divisor.
+func (r Reciprocal32) DivRem(hi, lo uint32) (quo, rem uint32) {
+	if hi >= r.d>>r.s {
+		panic(ErrQuotientOverflow)
+	}
+	u1 := hi<<r.s | lo>>(32-r.s)
+	u0 := lo << r.s
+	// See divRem2by1_64.
+	q, q0 := Mul32(r.v, u1)
+	var c uint32
+	q0, c = Add32(q0, u0, 0)
+	q, _ = Add32(q, u1+1, c)
+	rem = u0 - q*r.d
+	if rem > q0 {
+		q--
+		rem += r.d
+	}
+	if rem >= r.d {
+		q++
+		rem -= r.d
+	}
+	return q, rem >> r.s
+}
+
+// A Reciprocal128 is a precomputed reciprocal of a 128-bit divisor,
+// for dividing three-word dividends by the same divisor with Div3by2.
+type Reciprocal128 struct {
+	d1, d0 uint64 // divisor, normalized so that its most significant bit is set
+	v      uint64 // reciprocal of (d1 || d0)
+	s      uint   // normalization shift
+}
+
+// NewReciprocal128 returns the reciprocal of (d1 || d0),
+// where d1 and d0 hold the most significant and least
+// significant 64 bits of the divisor.
+// NewReciprocal128 panics with ErrDivideByZero if the divisor is zero.
+func NewReciprocal128(d1, d0 uint64) Reciprocal128 {
+	if d1 == 0 && d0 == 0 {
+		panic(ErrDivideByZero)
+	}
+	var s uint
+	if d1 == 0 {
+		s = 64 + uint(LeadingZeros64(d0))
+	} else {
+		s = uint(LeadingZeros64(d1))
+	}
+	d := Uint128{d1, d0}.Lsh(s)
+	return Reciprocal128{d1: d.Hi, d0: d.Lo, v: reciprocal3by2_64(d.Hi, d.Lo), s: s}
+}
+
+// reciprocal3by2_64 returns floor((2^192-1)/(d1 || d0)) - 2^64
+// for a normalized divisor (d1 || d0).
+func reciprocal3by2_64(d1, d0 uint64) uint64 {
+	// See Möller and Granlund, "Improved division by invariant integers",
+	// IEEE Transactions on Computers 60 (2011), Algorithm 6.
+	//
+	// Start from the reciprocal of d1 alone, and decrement it
+	// while accounting for d0 would make it too large.
+
+	v := reciprocal64(d1)
+	p := d1*v + d0
+	if p < d0 {
+		v--
+		if p >= d1 {
+			v--
+			p -= d1
+		}
+		p -= d1
+	}
+	t1, t0 := Mul64(v, d0)
+	p += t1
+	if p < t1 {
+		v--
+		if p > d1 || p == d1 && t0 >= d0 {
+			v--
+		}
+	}
+	return v
+}
+
+// Divisor returns the most significant and least significant
+// 64 bits of the divisor that r was created from.
+func (r Reciprocal128) Divisor() (d1, d0 uint64) {
+	d := Uint128{r.d1, r.d0}.Rsh(r.s)
+	return d.Hi, d.Lo
+}
+
+// Div3by2 returns the 64-bit quotient and 128-bit remainder of
+// (u2 || u1 || u0) and the divisor, where u2, u1 and u0 hold the
+// dividend from its most significant to least significant 64 bits.
+// The remainder is returned as (r1 || r0).
+// Div3by2 panics with ErrQuotientOverflow if (u2 || u1) >= the divisor.
+func (r Reciprocal128) Div3by2(u2, u1, u0 uint64) (quo, r1, r0 uint64) {
+	d1, d0 := r.Divisor()
+	if u2 > d1 || u2 == d1 && u1 >= d0 {
+		panic(ErrQuotientOverflow)
+	}
+	// The dividend is less than the divisor times 2^64,
+	// so shifting it by s still fits in three words.
+	// If the divisor fits in a word, u2 is zero.
+	s := r.s
+	if s >= 64 {
+		u2, u1, u0 = u1, u0, 0
+		s -= 64
+	}
+	u2 = u2<<s | u1>>(64-s)
+	u1 = u1<<s | u0>>(64-s)
+	u0 <<= s
+	quo, rem := divRem3by2_64(u2, u1, u0, r.d1, r.d0, r.v)
+	rem = rem.Rsh(r.s)
+	return quo, rem.Hi, rem.Lo
+}
+
+// divRem3by2_64 returns the quotient and remainder of (u2 || u1 || u0)
+// and the normalized divisor (d1 || d0), where v is the reciprocal
+// of the divisor and (u2 || u1) < (d1 || d0).
+func divRem3by2_64(u2, u1, u0, d1, d0, v uint64) (q uint64, r Uint128) {
+	// See Möller and Granlund, "Improved division by invariant integers",
+	// IEEE Transactions on Computers 60 (2011), Algorithm 5.
+	//
+	// Estimate the quotient as the high word of v*u2 + (u2 || u1),
+	// plus one. The candidate remainder tells whether the estimate
+	// was one too large, and rarely, one too small.
+
+	q, q0 := Mul64(v, u2)
+	var c uint64
+	q0, c = Add64(q0, u1, 0)
+	q, _ = Add64(q, u2, c)
+	d := Uint128{d1, d0}
+	t1, t0 := Mul64(d0, q)
+	r = Uint128{u1 - q*d1, u0}.Sub(Uint128{t1, t0}).Sub(d)
+	q++
+	if r.Hi >= q0 {
+		q--
+		r = r.Add(d)
+	}
+	if r.Cmp(d) >= 0 {
+		q++
+		r = r.Sub(d)
+	}
+	return
+}
+
+// Div3by2_64 returns the 64-bit quotient and 128-bit remainder of
+// (u2 || u1 || u0) and (d1 || d0), where u2, u1 and u0 hold the
+// dividend from its most significant to least significant 64 bits,
+// and d1 and d0 hold the most significant and least significant
+// 64 bits of the divisor. The remainder is returned as (r1 || r0).
+// To divide many dividends by the same divisor, use NewReciprocal128.
+// Div3by2_64 panics with ErrDivideByZero if the divisor is zero,
+// and with ErrQuotientOverflow if (u2 || u1) >= (d1 || d0).
+func Div3by2_64(u2, u1, u0, d1, d0 uint64) (quo, r1, r0 uint64) {
+	return NewReciprocal128(d1, d0).Div3by2(u2, u1, u0)
+}
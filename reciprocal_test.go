@@ -0,0 +1,145 @@
+// MIT License
+
+// Copyright (c) 2018 Akhil Indurti
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package extprec
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestReciprocal64(t *testing.T) {
+	for _, f := range factors64 {
+		if f.x == 0 {
+			continue
+		}
+		r := NewReciprocal64(f.x)
+		if d := r.Divisor(); d != f.x {
+			t.Errorf("NewReciprocal64(0x%X).Divisor() == 0x%X", f.x, d)
+		}
+		quo, rem := r.DivRem(f.hi, f.lo)
+		if quo != f.y || rem != f.rem {
+			t.Errorf("NewReciprocal64(0x%X).DivRem(0x%X, 0x%X) == (0x%X, 0x%X); want (0x%X, 0x%X)", f.x, f.hi, f.lo, quo, rem, f.y, f.rem)
+		}
+	}
+	rnd := rand.New(rand.NewSource(9))
+	for i := 0; i < 10000; i++ {
+		x := rnd.Uint64() >> uint(rnd.Intn(64))
+		if x == 0 {
+			continue
+		}
+		hi, lo := rnd.Uint64()%x, rnd.Uint64()
+		if i%4 == 0 {
+			hi = x - 1
+		}
+		wantQ, wantR := Div64(hi, lo, x)
+		if quo, rem := NewReciprocal64(x).DivRem(hi, lo); quo != wantQ || rem != wantR {
+			t.Errorf("NewReciprocal64(0x%X).DivRem(0x%X, 0x%X) == (0x%X, 0x%X); want (0x%X, 0x%X)", x, hi, lo, quo, rem, wantQ, wantR)
+		}
+	}
+	if err := divPanic(func() { NewReciprocal64(0) }); err != ErrDivideByZero {
+		t.Errorf("NewReciprocal64(0) panicked with %v; want %v", err, ErrDivideByZero)
+	}
+	if err := divPanic(func() { NewReciprocal64(3).DivRem(3, 0) }); err != ErrQuotientOverflow {
+		t.Errorf("NewReciprocal64(3).DivRem(3, 0) panicked with %v; want %v", err, ErrQuotientOverflow)
+	}
+}
+
+func TestReciprocal32(t *testing.T) {
+	for _, f := range factors32 {
+		if f.x == 0 {
+			continue
+		}
+		r := NewReciprocal32(f.x)
+		if d := r.Divisor(); d != f.x {
+			t.Errorf("NewReciprocal32(0x%X).Divisor() == 0x%X", f.x, d)
+		}
+		quo, rem := r.DivRem(f.hi, f.lo)
+		if quo != f.y || rem != f.rem {
+			t.Errorf("NewReciprocal32(0x%X).DivRem(0x%X, 0x%X) == (0x%X, 0x%X); want (0x%X, 0x%X)", f.x, f.hi, f.lo, quo, rem, f.y, f.rem)
+		}
+	}
+	rnd := rand.New(rand.NewSource(10))
+	for i := 0; i < 10000; i++ {
+		x := rnd.Uint32() >> uint(rnd.Intn(32))
+		if x == 0 {
+			continue
+		}
+		hi, lo := rnd.Uint32()%x, rnd.Uint32()
+		if i%4 == 0 {
+			hi = x - 1
+		}
+		wantQ, wantR := Div32(hi, lo, x)
+		if quo, rem := NewReciprocal32(x).DivRem(hi, lo); quo != wantQ || rem != wantR {
+			t.Errorf("NewReciprocal32(0x%X).DivRem(0x%X, 0x%X) == (0x%X, 0x%X); want (0x%X, 0x%X)", x, hi, lo, quo, rem, wantQ, wantR)
+		}
+	}
+}
+
+func TestDiv3by2(t *testing.T) {
+	// Divisors and dividends built from the edge cases in factors64.
+	var vals []Uint128
+	for _, f := range factors64 {
+		vals = append(vals, Uint128{f.hi, f.lo}, Uint128{f.x, f.y}, Uint128{f.lo, f.hi})
+	}
+	lows := []uint64{0, 1, 0xFF, 1<<63 - 1, 1 << 63, 1<<64 - 1}
+	for _, d := range vals {
+		if d.IsZero() {
+			continue
+		}
+		r := NewReciprocal128(d.Hi, d.Lo)
+		if d1, d0 := r.Divisor(); d1 != d.Hi || d0 != d.Lo {
+			t.Errorf("NewReciprocal128(0x%X, 0x%X).Divisor() == (0x%X, 0x%X)", d.Hi, d.Lo, d1, d0)
+		}
+		bd := u128ToBig(d)
+		for _, u := range vals {
+			// Reduce the top two words below the divisor.
+			_, u = u.QuoRem(d)
+			for _, u0 := range lows {
+				bu := new(big.Int).Lsh(u128ToBig(u), 64)
+				bu.Or(bu, new(big.Int).SetUint64(u0))
+				bq, br := new(big.Int).QuoRem(bu, bd, new(big.Int))
+				want := bigToU128(br)
+				quo, r1, r0 := r.Div3by2(u.Hi, u.Lo, u0)
+				if quo != bq.Uint64() || r1 != want.Hi || r0 != want.Lo {
+					t.Errorf("Div3by2_64(0x%X, 0x%X, 0x%X, 0x%X, 0x%X) == (0x%X, 0x%X, 0x%X); want (0x%X, 0x%X, 0x%X)",
+						u.Hi, u.Lo, u0, d.Hi, d.Lo,
+						quo, r1, r0,
+						bq.Uint64(), want.Hi, want.Lo)
+				}
+				if q2, r12, r02 := Div3by2_64(u.Hi, u.Lo, u0, d.Hi, d.Lo); q2 != quo || r12 != r1 || r02 != r0 {
+					t.Errorf("Div3by2_64(0x%X, 0x%X, 0x%X, 0x%X, 0x%X) == (0x%X, 0x%X, 0x%X); want (0x%X, 0x%X, 0x%X)",
+						u.Hi, u.Lo, u0, d.Hi, d.Lo,
+						q2, r12, r02,
+						quo, r1, r0)
+				}
+			}
+		}
+	}
+	if err := divPanic(func() { Div3by2_64(0, 0, 0, 0, 0) }); err != ErrDivideByZero {
+		t.Errorf("Div3by2_64(0, 0, 0, 0, 0) panicked with %v; want %v", err, ErrDivideByZero)
+	}
+	if err := divPanic(func() { Div3by2_64(1, 2, 0, 1, 2) }); err != ErrQuotientOverflow {
+		t.Errorf("Div3by2_64(1, 2, 0, 1, 2) panicked with %v; want %v", err, ErrQuotientOverflow)
+	}
+}
@@ -0,0 +1,124 @@
+// MIT License
+
+// Copyright (c) 2018 Akhil Indurti
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package extprec
+
+import . "math/bits"
+
+// Sqrt64 returns the integer square root root = floor(sqrt(x))
+// and the remainder rem = x - root^2.
+func Sqrt64(x uint64) (root, rem uint64) {
+	// See "Hacker's Delight", Ch. 11-1: Integer Square Root
+	//
+	// Newton's method, starting from a power of two at least sqrt(x),
+	// decreases monotonically to the floor of the root.
+	if x == 0 {
+		return 0, 0
+	}
+	s := uint64(1) << uint((Len64(x)+1)/2)
+	for {
+		t := (s + x/s) >> 1
+		if t >= s {
+			break
+		}
+		s = t
+	}
+	return s, x - s*s
+}
+
+// Sqrt128 returns the integer square root root = floor(sqrt(hi || lo))
+// and the remainder (remHi || remLo) = (hi || lo) - root^2.
+// The remainder is at most 2*root, so remHi is 0 or 1.
+func Sqrt128(hi, lo uint64) (root, remHi, remLo uint64) {
+	// See "Hacker's Delight", Ch. 11-1: Integer Square Root
+	if hi == 0 {
+		root, remLo = Sqrt64(lo)
+		return root, 0, remLo
+	}
+	s := ^uint64(0)
+	if n := uint((Len64(hi) + 65) / 2); n < 64 {
+		s = 1 << n
+	}
+	// While s > floor(sqrt(x)), x/s < s, so hi < s and Div64 cannot
+	// overflow. Once hi >= s, the next iterate would not decrease.
+	for hi < s {
+		q, _ := Div64(hi, lo, s)
+		sum, carry := Add64(s, q, 0)
+		t := carry<<63 | sum>>1
+		if t >= s {
+			break
+		}
+		s = t
+	}
+	sh, sl := Mul64(s, s)
+	remLo, borrow := Sub64(lo, sl, 0)
+	remHi, _ = Sub64(hi, sh, borrow)
+	return s, remHi, remLo
+}
+
+// Cbrt64 returns the integer cube root root = floor(cbrt(x))
+// and the remainder rem = x - root^3.
+func Cbrt64(x uint64) (root, rem uint64) {
+	// See "Hacker's Delight", Ch. 11-2: Integer Cube Root
+	//
+	// As for Sqrt64, Newton's method started above the root
+	// decreases monotonically to its floor.
+	if x == 0 {
+		return 0, 0
+	}
+	s := uint64(1) << uint((Len64(x)+2)/3)
+	for {
+		t := (2*s + x/(s*s)) / 3
+		if t >= s {
+			break
+		}
+		s = t
+	}
+	return s, x - s*s*s
+}
+
+// Cbrt128 returns the integer cube root root = floor(cbrt(hi || lo))
+// and the remainder (remHi || remLo) = (hi || lo) - root^3.
+func Cbrt128(hi, lo uint64) (root, remHi, remLo uint64) {
+	// See "Hacker's Delight", Ch. 11-2: Integer Cube Root
+	if hi == 0 {
+		root, remLo = Cbrt64(lo)
+		return root, 0, remLo
+	}
+	// The root is less than 2^43, so s^2 may need two words;
+	// divide by s twice instead. While s >= cbrt(x), x/s^2 <= s
+	// fits in a word.
+	s := uint64(1) << uint((Len64(hi)+66)/3)
+	for {
+		qhi, qlo := Quo64(hi, lo, s)
+		_, q := Quo64(qhi, qlo, s)
+		t := (2*s + q) / 3
+		if t >= s {
+			break
+		}
+		s = t
+	}
+	h, l := Mul64(s, s)
+	c := Uint128{h, l}.mul64(s)
+	r := Uint128{hi, lo}.Sub(c)
+	return s, r.Hi, r.Lo
+}
@@ -0,0 +1,86 @@
+// MIT License
+
+// Copyright (c) 2018 Akhil Indurti
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package extprec
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// rootValues returns values around perfect squares and cubes,
+// together with random values of every bit length.
+func rootValues(r *rand.Rand) []Uint128 {
+	x := u128Values()
+	for i := 0; i < 200; i++ {
+		s := r.Uint64() >> uint(r.Intn(64))
+		c := s >> 21
+		sh, sl := Mul64(s, s)
+		ch, cl := Mul64(c, c)
+		for _, v := range []Uint128{{sh, sl}, Uint128{ch, cl}.mul64(c)} {
+			x = append(x, v, v.Add(Uint128{0, 1}), v.Sub(Uint128{0, 1}))
+		}
+		x = append(x, Uint128{0, r.Uint64() >> uint(r.Intn(64))})
+		x = append(x, Uint128{r.Uint64() >> uint(r.Intn(64)), r.Uint64()})
+	}
+	return x
+}
+
+func TestSqrt(t *testing.T) {
+	r := rand.New(rand.NewSource(27))
+	for _, x := range rootValues(r) {
+		bx := u128ToBig(x)
+		s := new(big.Int).Sqrt(bx)
+		rem := new(big.Int).Sub(bx, new(big.Int).Mul(s, s))
+		want, wantRem := s.Uint64(), bigToU128(rem)
+		root, remHi, remLo := Sqrt128(x.Hi, x.Lo)
+		if root != want || remHi != wantRem.Hi || remLo != wantRem.Lo {
+			t.Errorf("Sqrt128(%v) == (%d, 0x%X, 0x%X); want (%d, %v)", x, root, remHi, remLo, want, wantRem)
+		}
+		if x.Hi == 0 {
+			if root, rem := Sqrt64(x.Lo); root != want || rem != wantRem.Lo {
+				t.Errorf("Sqrt64(%d) == (%d, %d); want (%d, %d)", x.Lo, root, rem, want, wantRem.Lo)
+			}
+		}
+	}
+}
+
+func TestCbrt(t *testing.T) {
+	r := rand.New(rand.NewSource(28))
+	cube := func(c *big.Int) *big.Int { return new(big.Int).Mul(c, new(big.Int).Mul(c, c)) }
+	for _, x := range rootValues(r) {
+		bx := u128ToBig(x)
+		root, remHi, remLo := Cbrt128(x.Hi, x.Lo)
+		c := new(big.Int).SetUint64(root)
+		rem := new(big.Int).Sub(bx, cube(c))
+		next := cube(new(big.Int).Add(c, big.NewInt(1)))
+		if rem.Sign() < 0 || next.Cmp(bx) <= 0 || u128ToBig(Uint128{remHi, remLo}).Cmp(rem) != 0 {
+			t.Errorf("Cbrt128(%v) == (%d, 0x%X, 0x%X)", x, root, remHi, remLo)
+		}
+		if x.Hi == 0 {
+			if r64, rem64 := Cbrt64(x.Lo); r64 != root || rem64 != remLo {
+				t.Errorf("Cbrt64(%d) == (%d, %d); want (%d, %d)", x.Lo, r64, rem64, root, remLo)
+			}
+		}
+	}
+}
@@ -0,0 +1,198 @@
+// MIT License
+
+// Copyright (c) 2018 Akhil Indurti
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package extprec
+
+import . "math/bits"
+
+// AddSat returns the sum of x and y,
+// clamped to the largest uint on overflow.
+func AddSat(x, y uint) uint {
+	if UintSize == 32 {
+		return uint(AddSat32(uint32(x), uint32(y)))
+	}
+	return uint(AddSat64(uint64(x), uint64(y)))
+}
+
+// AddSat32 returns the 32-bit sum of x and y,
+// clamped to the largest uint32 on overflow.
+func AddSat32(x, y uint32) uint32 {
+	sum, carry := Add32(x, y, 0)
+	// -carry is all ones exactly when the sum wrapped around.
+	return sum | -carry
+}
+
+// AddSat64 returns the 64-bit sum of x and y,
+// clamped to the largest uint64 on overflow.
+func AddSat64(x, y uint64) uint64 {
+	sum, carry := Add64(x, y, 0)
+	return sum | -carry
+}
+
+// SubSat returns the difference of x and y,
+// clamped to zero on underflow.
+func SubSat(x, y uint) uint {
+	if UintSize == 32 {
+		return uint(SubSat32(uint32(x), uint32(y)))
+	}
+	return uint(SubSat64(uint64(x), uint64(y)))
+}
+
+// SubSat32 returns the 32-bit difference of x and y,
+// clamped to zero on underflow.
+func SubSat32(x, y uint32) uint32 {
+	difference, borrow := Sub32(x, y, 0)
+	// -borrow is all ones exactly when the difference wrapped around.
+	return difference &^ -borrow
+}
+
+// SubSat64 returns the 64-bit difference of x and y,
+// clamped to zero on underflow.
+func SubSat64(x, y uint64) uint64 {
+	difference, borrow := Sub64(x, y, 0)
+	return difference &^ -borrow
+}
+
+// MulSat returns the product of x and y,
+// clamped to the largest uint on overflow.
+func MulSat(x, y uint) uint {
+	if UintSize == 32 {
+		return uint(MulSat32(uint32(x), uint32(y)))
+	}
+	return uint(MulSat64(uint64(x), uint64(y)))
+}
+
+// MulSat32 returns the 32-bit product of x and y,
+// clamped to the largest uint32 on overflow.
+func MulSat32(x, y uint32) uint32 {
+	hi, lo := Mul32(x, y)
+	if hi != 0 {
+		return 1<<32 - 1
+	}
+	return lo
+}
+
+// MulSat64 returns the 64-bit product of x and y,
+// clamped to the largest uint64 on overflow.
+func MulSat64(x, y uint64) uint64 {
+	hi, lo := Mul64(x, y)
+	if hi != 0 {
+		return 1<<64 - 1
+	}
+	return lo
+}
+
+// AddSatS returns the sum of x and y, clamped to the
+// smallest or largest int on overflow.
+func AddSatS(x, y int) int {
+	if UintSize == 32 {
+		return int(AddSatS32(int32(x), int32(y)))
+	}
+	return int(AddSatS64(int64(x), int64(y)))
+}
+
+// AddSatS32 returns the 32-bit sum of x and y, clamped to the
+// smallest or largest int32 on overflow.
+func AddSatS32(x, y int32) int32 {
+	// See "Hacker's Delight", Ch. 2-13: Overflow Detection
+	//
+	// The sum overflows if x and y have the same sign,
+	// and the sign of the sum differs from it. In that case
+	// the result saturates toward the sign of x.
+	s, _ := Add32(uint32(x), uint32(y), 0)
+	sum := int32(s)
+	o := ((x ^ sum) & (y ^ sum)) >> 31
+	return sum&^o | ((x>>31)^(1<<31-1))&o
+}
+
+// AddSatS64 returns the 64-bit sum of x and y, clamped to the
+// smallest or largest int64 on overflow.
+func AddSatS64(x, y int64) int64 {
+	s, _ := Add64(uint64(x), uint64(y), 0)
+	sum := int64(s)
+	o := ((x ^ sum) & (y ^ sum)) >> 63
+	return sum&^o | ((x>>63)^(1<<63-1))&o
+}
+
+// SubSatS returns the difference of x and y, clamped to the
+// smallest or largest int on overflow.
+func SubSatS(x, y int) int {
+	if UintSize == 32 {
+		return int(SubSatS32(int32(x), int32(y)))
+	}
+	return int(SubSatS64(int64(x), int64(y)))
+}
+
+// SubSatS32 returns the 32-bit difference of x and y, clamped to the
+// smallest or largest int32 on overflow.
+func SubSatS32(x, y int32) int32 {
+	// See "Hacker's Delight", Ch. 2-13: Overflow Detection
+	//
+	// The difference overflows if x and y have opposite signs,
+	// and the sign of the difference differs from that of x.
+	// In that case the result saturates toward the sign of x.
+	d, _ := Sub32(uint32(x), uint32(y), 0)
+	difference := int32(d)
+	o := ((x ^ y) & (x ^ difference)) >> 31
+	return difference&^o | ((x>>31)^(1<<31-1))&o
+}
+
+// SubSatS64 returns the 64-bit difference of x and y, clamped to the
+// smallest or largest int64 on overflow.
+func SubSatS64(x, y int64) int64 {
+	d, _ := Sub64(uint64(x), uint64(y), 0)
+	difference := int64(d)
+	o := ((x ^ y) & (x ^ difference)) >> 63
+	return difference&^o | ((x>>63)^(1<<63-1))&o
+}
+
+// MulSatS returns the product of x and y, clamped to the
+// smallest or largest int on overflow.
+func MulSatS(x, y int) int {
+	if UintSize == 32 {
+		return int(MulSatS32(int32(x), int32(y)))
+	}
+	return int(MulSatS64(int64(x), int64(y)))
+}
+
+// MulSatS32 returns the 32-bit product of x and y, clamped to the
+// smallest or largest int32 on overflow.
+func MulSatS32(x, y int32) int32 {
+	hi, lo := MulS32(x, y)
+	product := int32(lo)
+	if hi != product>>31 {
+		// The result saturates toward the sign of the true product.
+		return ((x ^ y) >> 31) ^ (1<<31 - 1)
+	}
+	return product
+}
+
+// MulSatS64 returns the 64-bit product of x and y, clamped to the
+// smallest or largest int64 on overflow.
+func MulSatS64(x, y int64) int64 {
+	hi, lo := MulS64(x, y)
+	product := int64(lo)
+	if hi != product>>63 {
+		return ((x ^ y) >> 63) ^ (1<<63 - 1)
+	}
+	return product
+}
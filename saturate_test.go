@@ -0,0 +1,120 @@
+// MIT License
+
+// Copyright (c) 2018 Akhil Indurti
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package extprec
+
+import (
+	"math/big"
+	"math/bits"
+	"testing"
+)
+
+// clamp returns z limited to the range [min, max].
+func clamp(z *big.Int, min, max *big.Int) *big.Int {
+	switch {
+	case z.Cmp(min) < 0:
+		return min
+	case z.Cmp(max) > 0:
+		return max
+	}
+	return z
+}
+
+func TestSatUnsigned(t *testing.T) {
+	words := []uint64{0, 1, 2, 3, 0xFF, 0xFFFF, 1<<31 - 1, 1 << 31, 1<<32 - 1, 1 << 32, 1<<63 - 1, 1 << 63, 1<<64 - 2, 1<<64 - 1}
+	zero := new(big.Int)
+	max32 := new(big.Int).SetUint64(1<<32 - 1)
+	max64 := new(big.Int).SetUint64(1<<64 - 1)
+	ops := []struct {
+		name string
+		f32  func(x, y uint32) uint32
+		f64  func(x, y uint64) uint64
+		f    func(x, y uint) uint
+		op   func(z, x, y *big.Int) *big.Int
+	}{
+		{"AddSat", AddSat32, AddSat64, AddSat, (*big.Int).Add},
+		{"SubSat", SubSat32, SubSat64, SubSat, (*big.Int).Sub},
+		{"MulSat", MulSat32, MulSat64, MulSat, (*big.Int).Mul},
+	}
+	for _, op := range ops {
+		for _, x := range words {
+			for _, y := range words {
+				bx, by := new(big.Int).SetUint64(x), new(big.Int).SetUint64(y)
+				want64 := clamp(op.op(new(big.Int), bx, by), zero, max64).Uint64()
+				if got := op.f64(x, y); got != want64 {
+					t.Errorf("%s64(0x%X, 0x%X) == 0x%X; want 0x%X", op.name, x, y, got, want64)
+				}
+				x32, y32 := uint32(x), uint32(y)
+				bx, by = new(big.Int).SetUint64(uint64(x32)), new(big.Int).SetUint64(uint64(y32))
+				want32 := uint32(clamp(op.op(new(big.Int), bx, by), zero, max32).Uint64())
+				if got := op.f32(x32, y32); got != want32 {
+					t.Errorf("%s32(0x%X, 0x%X) == 0x%X; want 0x%X", op.name, x32, y32, got, want32)
+				}
+				want := uint(want64)
+				if bits.UintSize == 32 {
+					want = uint(want32)
+				}
+				if got := op.f(uint(x), uint(y)); got != want {
+					t.Errorf("%s(0x%X, 0x%X) == 0x%X; want 0x%X", op.name, uint(x), uint(y), got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestSatSigned(t *testing.T) {
+	min32, max32 := big.NewInt(-1<<31), big.NewInt(1<<31-1)
+	min64, max64 := big.NewInt(-1<<63), big.NewInt(1<<63-1)
+	ops := []struct {
+		name string
+		f32  func(x, y int32) int32
+		f64  func(x, y int64) int64
+		f    func(x, y int) int
+		op   func(z, x, y *big.Int) *big.Int
+	}{
+		{"AddSatS", AddSatS32, AddSatS64, AddSatS, (*big.Int).Add},
+		{"SubSatS", SubSatS32, SubSatS64, SubSatS, (*big.Int).Sub},
+		{"MulSatS", MulSatS32, MulSatS64, MulSatS, (*big.Int).Mul},
+	}
+	for _, op := range ops {
+		for _, x := range signed64 {
+			for _, y := range signed64 {
+				want64 := clamp(op.op(new(big.Int), big.NewInt(x), big.NewInt(y)), min64, max64).Int64()
+				if got := op.f64(x, y); got != want64 {
+					t.Errorf("%s64(%d, %d) == %d; want %d", op.name, x, y, got, want64)
+				}
+				x32, y32 := int32(x), int32(y)
+				want32 := int32(clamp(op.op(new(big.Int), big.NewInt(int64(x32)), big.NewInt(int64(y32))), min32, max32).Int64())
+				if got := op.f32(x32, y32); got != want32 {
+					t.Errorf("%s32(%d, %d) == %d; want %d", op.name, x32, y32, got, want32)
+				}
+				want := int(want64)
+				if bits.UintSize == 32 {
+					want = int(want32)
+				}
+				if got := op.f(int(x), int(y)); got != want {
+					t.Errorf("%s(%d, %d) == %d; want %d", op.name, int(x), int(y), got, want)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// MIT License
+
+// Copyright (c) 2018 Akhil Indurti
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package extprec
+
+import . "math/bits"
+
+// A Uint128 represents an unsigned 128-bit integer.
+// Hi and Lo hold the most significant and least
+// significant 64 bits of the value.
+//
+// Arithmetic on a Uint128 wraps around modulo 2^128.
+// The zero value is 0.
+type Uint128 struct {
+	Hi, Lo uint64
+}
+
+// Add returns the sum x+y, wrapping around on overflow.
+func (x Uint128) Add(y Uint128) Uint128 {
+	lo, c := Add64(x.Lo, y.Lo, 0)
+	hi, _ := Add64(x.Hi, y.Hi, c)
+	return Uint128{hi, lo}
+}
+
+// Sub returns the difference x-y, wrapping around on underflow.
+func (x Uint128) Sub(y Uint128) Uint128 {
+	lo, b := Sub64(x.Lo, y.Lo, 0)
+	hi, _ := Sub64(x.Hi, y.Hi, b)
+	return Uint128{hi, lo}
+}
+
+// Mul returns the least significant 128 bits of the product x*y.
+func (x Uint128) Mul(y Uint128) Uint128 {
+	hi, lo := Mul64(x.Lo, y.Lo)
+	hi += x.Hi*y.Lo + x.Lo*y.Hi
+	return Uint128{hi, lo}
+}
+
+// mul64 returns the least significant 128 bits of the product x*y.
+func (x Uint128) mul64(y uint64) Uint128 {
+	hi, lo := Mul64(x.Lo, y)
+	hi += x.Hi * y
+	return Uint128{hi, lo}
+}
+
+// QuoRem returns the quotient x/y and remainder x%y.
+// QuoRem panics if y is zero.
+func (x Uint128) QuoRem(y Uint128) (q, r Uint128) {
+	if y.Hi == 0 {
+		// Divide by a single word, one digit at a time.
+		if x.Hi < y.Lo {
+			q.Lo, r.Lo = Div64(x.Hi, x.Lo, y.Lo)
+			return
+		}
+		var rhi uint64
+		q.Hi, rhi = Div64(0, x.Hi, y.Lo)
+		q.Lo, r.Lo = Div64(rhi, x.Lo, y.Lo)
+		return
+	}
+	// See "Hacker's Delight", Ch. 9-5: Doubleword Division from Long Division
+	//
+	// Normalize the divisor so that its most significant bit is set,
+	// and shift the dividend right by one so the estimate cannot overflow.
+	// The estimated quotient is then too large by at most one.
+	n := uint(LeadingZeros64(y.Hi))
+	v1 := y.Lsh(n)
+	u1 := x.Rsh(1)
+	tq, _ := Div64(u1.Hi, u1.Lo, v1.Hi)
+	tq >>= 63 - n
+	if tq != 0 {
+		tq--
+	}
+	q = Uint128{0, tq}
+	r = x.Sub(y.mul64(tq))
+	if r.Cmp(y) >= 0 {
+		q = q.Add(Uint128{0, 1})
+		r = r.Sub(y)
+	}
+	return
+}
+
+// Cmp compares x and y and returns:
+//
+//	-1 if x <  y
+//	 0 if x == y
+//	+1 if x >  y
+func (x Uint128) Cmp(y Uint128) int {
+	switch {
+	case x.Hi < y.Hi, x.Hi == y.Hi && x.Lo < y.Lo:
+		return -1
+	case x == y:
+		return 0
+	}
+	return +1
+}
+
+// Lsh returns x shifted left by n bits.
+// Bits shifted out are discarded.
+func (x Uint128) Lsh(n uint) Uint128 {
+	switch {
+	case n >= 128:
+		return Uint128{}
+	case n >= 64:
+		return Uint128{x.Lo << (n - 64), 0}
+	}
+	return Uint128{x.Hi<<n | x.Lo>>(64-n), x.Lo << n}
+}
+
+// Rsh returns x shifted right by n bits.
+// Bits shifted out are discarded.
+func (x Uint128) Rsh(n uint) Uint128 {
+	switch {
+	case n >= 128:
+		return Uint128{}
+	case n >= 64:
+		return Uint128{0, x.Hi >> (n - 64)}
+	}
+	return Uint128{x.Hi >> n, x.Lo>>n | x.Hi<<(64-n)}
+}
+
+// And returns the bitwise AND x&y.
+func (x Uint128) And(y Uint128) Uint128 {
+	return Uint128{x.Hi & y.Hi, x.Lo & y.Lo}
+}
+
+// Or returns the bitwise OR x|y.
+func (x Uint128) Or(y Uint128) Uint128 {
+	return Uint128{x.Hi | y.Hi, x.Lo | y.Lo}
+}
+
+// Xor returns the bitwise XOR x^y.
+func (x Uint128) Xor(y Uint128) Uint128 {
+	return Uint128{x.Hi ^ y.Hi, x.Lo ^ y.Lo}
+}
+
+// Not returns the bitwise complement ^x.
+func (x Uint128) Not() Uint128 {
+	return Uint128{^x.Hi, ^x.Lo}
+}
+
+// IsZero reports whether x is zero.
+func (x Uint128) IsZero() bool {
+	return x.Hi|x.Lo == 0
+}
@@ -0,0 +1,137 @@
+// MIT License
+
+// Copyright (c) 2018 Akhil Indurti
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package extprec
+
+import (
+	"math/big"
+	"testing"
+)
+
+var (
+	two64  = new(big.Int).Lsh(big.NewInt(1), 64)
+	two128 = new(big.Int).Lsh(big.NewInt(1), 128)
+)
+
+// u128Values holds a spread of 128-bit values built from factors64,
+// including the extremes of each word.
+func u128Values() []Uint128 {
+	words := []uint64{0, 1, 2, 3, 0xFF, 1 << 32, 1<<63 - 1, 1 << 63, 1<<64 - 2, 1<<64 - 1}
+	vals := make([]Uint128, 0, len(words)*len(words)+len(factors64))
+	for _, hi := range words {
+		for _, lo := range words {
+			vals = append(vals, Uint128{hi, lo})
+		}
+	}
+	for _, f := range factors64 {
+		vals = append(vals, Uint128{f.hi, f.lo})
+	}
+	return vals
+}
+
+func u128ToBig(x Uint128) *big.Int {
+	z := new(big.Int).SetUint64(x.Hi)
+	z.Lsh(z, 64)
+	return z.Or(z, new(big.Int).SetUint64(x.Lo))
+}
+
+func bigToU128(z *big.Int) Uint128 {
+	z = new(big.Int).Mod(z, two128)
+	lo := new(big.Int).Mod(z, two64)
+	hi := new(big.Int).Rsh(z, 64)
+	return Uint128{hi.Uint64(), lo.Uint64()}
+}
+
+func TestUint128Arith(t *testing.T) {
+	vals := u128Values()
+	for _, x := range vals {
+		bx := u128ToBig(x)
+		for _, y := range vals {
+			by := u128ToBig(y)
+			if got, want := x.Add(y), bigToU128(new(big.Int).Add(bx, by)); got != want {
+				t.Errorf("%v.Add(%v) == %v; want %v", x, y, got, want)
+			}
+			if got, want := x.Sub(y), bigToU128(new(big.Int).Sub(bx, by)); got != want {
+				t.Errorf("%v.Sub(%v) == %v; want %v", x, y, got, want)
+			}
+			if got, want := x.Mul(y), bigToU128(new(big.Int).Mul(bx, by)); got != want {
+				t.Errorf("%v.Mul(%v) == %v; want %v", x, y, got, want)
+			}
+			if got, want := x.Cmp(y), bx.Cmp(by); got != want {
+				t.Errorf("%v.Cmp(%v) == %d; want %d", x, y, got, want)
+			}
+			if y.IsZero() {
+				continue
+			}
+			q, r := x.QuoRem(y)
+			bq, br := new(big.Int).QuoRem(bx, by, new(big.Int))
+			if q != bigToU128(bq) || r != bigToU128(br) {
+				t.Errorf("%v.QuoRem(%v) == (%v, %v); want (%v, %v)", x, y, q, r, bigToU128(bq), bigToU128(br))
+			}
+		}
+	}
+}
+
+func TestUint128Div(t *testing.T) {
+	for _, f := range factors64 {
+		x := Uint128{f.hi, f.lo}
+		if f.x != 0 {
+			q, r := x.QuoRem(Uint128{0, f.x})
+			if q != (Uint128{0, f.y}) || r != (Uint128{0, f.rem}) {
+				t.Errorf("%v.QuoRem(0x%X) == (%v, %v); want (0x%X, 0x%X)", x, f.x, q, r, f.y, f.rem)
+			}
+		}
+	}
+}
+
+func TestUint128Bits(t *testing.T) {
+	vals := u128Values()
+	for _, x := range vals {
+		bx := u128ToBig(x)
+		for n := uint(0); n <= 130; n++ {
+			if got, want := x.Lsh(n), bigToU128(new(big.Int).Lsh(bx, n)); got != want {
+				t.Errorf("%v.Lsh(%d) == %v; want %v", x, n, got, want)
+			}
+			if got, want := x.Rsh(n), bigToU128(new(big.Int).Rsh(bx, n)); got != want {
+				t.Errorf("%v.Rsh(%d) == %v; want %v", x, n, got, want)
+			}
+		}
+		for _, y := range vals {
+			by := u128ToBig(y)
+			if got, want := x.And(y), bigToU128(new(big.Int).And(bx, by)); got != want {
+				t.Errorf("%v.And(%v) == %v; want %v", x, y, got, want)
+			}
+			if got, want := x.Or(y), bigToU128(new(big.Int).Or(bx, by)); got != want {
+				t.Errorf("%v.Or(%v) == %v; want %v", x, y, got, want)
+			}
+			if got, want := x.Xor(y), bigToU128(new(big.Int).Xor(bx, by)); got != want {
+				t.Errorf("%v.Xor(%v) == %v; want %v", x, y, got, want)
+			}
+		}
+		if got, want := x.Not(), bigToU128(new(big.Int).Sub(two128, new(big.Int).Add(bx, big.NewInt(1)))); got != want {
+			t.Errorf("%v.Not() == %v; want %v", x, got, want)
+		}
+		if got, want := x.IsZero(), bx.Sign() == 0; got != want {
+			t.Errorf("%v.IsZero() == %t; want %t", x, got, want)
+		}
+	}
+}
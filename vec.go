@@ -0,0 +1,291 @@
+// MIT License
+
+// Copyright (c) 2018 Akhil Indurti
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package extprec
+
+import . "math/bits"
+
+// The functions in this file operate on natural numbers stored as
+// slices of words in little-endian order: x[0] is the least
+// significant word. Unless stated otherwise, the destination z may
+// be the same slice as an operand, but must not otherwise overlap it,
+// and each operand must be at least as long as z.
+
+// AddVV sets z to the sum x+y of the first len(z) words
+// of x and y, and returns the carry-out bit (0 or 1).
+func AddVV(z, x, y []uint) (c uint) {
+	for i := range z {
+		z[i], c = Add(x[i], y[i], c)
+	}
+	return
+}
+
+// SubVV sets z to the difference x-y of the first len(z) words
+// of x and y, and returns the borrow-out bit (0 or 1).
+func SubVV(z, x, y []uint) (c uint) {
+	for i := range z {
+		z[i], c = Sub(x[i], y[i], c)
+	}
+	return
+}
+
+// AddVW sets z to the sum x+y of the first len(z) words of x
+// and the single word y, and returns the carry-out bit (0 or 1).
+// If z is empty, AddVW returns y.
+func AddVW(z, x []uint, y uint) (c uint) {
+	c = y
+	for i := range z {
+		z[i], c = Add(x[i], c, 0)
+	}
+	return
+}
+
+// SubVW sets z to the difference x-y of the first len(z) words of x
+// and the single word y, and returns the borrow-out bit (0 or 1).
+// If z is empty, SubVW returns y.
+func SubVW(z, x []uint, y uint) (c uint) {
+	c = y
+	for i := range z {
+		z[i], c = Sub(x[i], c, 0)
+	}
+	return
+}
+
+// MulAddVWW sets z to x*y+r over the first len(z) words of x,
+// and returns the most significant word of the result.
+func MulAddVWW(z, x []uint, y, r uint) (c uint) {
+	c = r
+	for i := range z {
+		hi, lo := Mul(x[i], y)
+		var cc uint
+		z[i], cc = Add(lo, c, 0)
+		c = hi + cc
+	}
+	return
+}
+
+// MulAddVWW32 sets z to x*y+r over the first len(z) words of x,
+// and returns the most significant word of the result.
+func MulAddVWW32(z, x []uint32, y, r uint32) (c uint32) {
+	c = r
+	for i := range z {
+		hi, lo := Mul32(x[i], y)
+		var cc uint32
+		z[i], cc = Add32(lo, c, 0)
+		c = hi + cc
+	}
+	return
+}
+
+// MulAddVWW64 sets z to x*y+r over the first len(z) words of x,
+// and returns the most significant word of the result.
+func MulAddVWW64(z, x []uint64, y, r uint64) (c uint64) {
+	c = r
+	for i := range z {
+		hi, lo := Mul64(x[i], y)
+		var cc uint64
+		z[i], cc = Add64(lo, c, 0)
+		c = hi + cc
+	}
+	return
+}
+
+// AddMulVVW sets z to z+x*y over the first len(z) words of x,
+// and returns the most significant word of the result.
+func AddMulVVW(z, x []uint, y uint) (c uint) {
+	for i := range z {
+		// z[i] + x[i]*y + c fits in two words:
+		// (2^n-1) + (2^n-1)^2 + (2^n-1) = 2^2n - 1.
+		hi, lo := Mul(x[i], y)
+		var c1, c2 uint
+		lo, c1 = Add(lo, z[i], 0)
+		z[i], c2 = Add(lo, c, 0)
+		c = hi + c1 + c2
+	}
+	return
+}
+
+// AddMulVVW32 sets z to z+x*y over the first len(z) words of x,
+// and returns the most significant word of the result.
+func AddMulVVW32(z, x []uint32, y uint32) (c uint32) {
+	for i := range z {
+		hi, lo := Mul32(x[i], y)
+		var c1, c2 uint32
+		lo, c1 = Add32(lo, z[i], 0)
+		z[i], c2 = Add32(lo, c, 0)
+		c = hi + c1 + c2
+	}
+	return
+}
+
+// AddMulVVW64 sets z to z+x*y over the first len(z) words of x,
+// and returns the most significant word of the result.
+func AddMulVVW64(z, x []uint64, y uint64) (c uint64) {
+	for i := range z {
+		hi, lo := Mul64(x[i], y)
+		var c1, c2 uint64
+		lo, c1 = Add64(lo, z[i], 0)
+		z[i], c2 = Add64(lo, c, 0)
+		c = hi + c1 + c2
+	}
+	return
+}
+
+// ShlVU sets z to x<<s over the first len(z) words of x, and returns
+// the bits shifted out of the most significant word, in the low bits
+// of c. The shift s must be less than UintSize; if s is zero, ShlVU
+// copies x to z and returns 0.
+func ShlVU(z, x []uint, s uint) (c uint) {
+	n := len(z)
+	if n == 0 {
+		return
+	}
+	// Walk from the most significant word down, so that
+	// each x[i-1] is read before z[i-1] overwrites it.
+	// A shift by UintSize-s is zero when s is zero.
+	cs := UintSize - s
+	c = x[n-1] >> cs
+	for i := n - 1; i > 0; i-- {
+		z[i] = x[i]<<s | x[i-1]>>cs
+	}
+	z[0] = x[0] << s
+	return
+}
+
+// ShrVU sets z to x>>s over the first len(z) words of x, and returns
+// the bits shifted out of the least significant word, in the high bits
+// of c. The shift s must be less than UintSize; if s is zero, ShrVU
+// copies x to z and returns 0.
+func ShrVU(z, x []uint, s uint) (c uint) {
+	n := len(z)
+	if n == 0 {
+		return
+	}
+	// Walk from the least significant word up, so that
+	// each x[i+1] is read before z[i+1] overwrites it.
+	cs := UintSize - s
+	c = x[0] << cs
+	for i := 0; i < n-1; i++ {
+		z[i] = x[i]>>s | x[i+1]<<cs
+	}
+	z[n-1] = x[n-1] >> s
+	return
+}
+
+// DivWVW sets z to the quotient of (xn || x) and y over the first
+// len(z) words of x, where xn is the most significant word of the
+// dividend, and returns the remainder.
+// DivWVW panics with ErrDivideByZero if y is zero,
+// and with ErrQuotientOverflow if xn >= y.
+func DivWVW(z []uint, xn uint, x []uint, y uint) (r uint) {
+	if err := checkDiv64(uint64(xn), uint64(y)); err != nil {
+		panic(err)
+	}
+	r = xn
+	// Each step divides a remainder less than y,
+	// so every quotient digit fits in a word.
+	for i := len(z) - 1; i >= 0; i-- {
+		z[i], r = Div(r, x[i], y)
+	}
+	return
+}
+
+// DivVW sets z to the quotient of the first len(z) words of x
+// and y, and returns the remainder.
+// DivVW panics with ErrDivideByZero if y is zero.
+func DivVW(z, x []uint, y uint) (r uint) {
+	return DivWVW(z, 0, x, y)
+}
+
+// DivVV sets q to the quotient and r to the remainder of u and v.
+// The quotient is stored in the first len(u)-len(v)+1 words of q,
+// and the remainder in the first len(v) words of r. q and r must
+// not overlap each other, but either may be the same slice as u or v.
+// DivVV panics with ErrDivideByZero if v is empty
+// or its most significant word is zero.
+func DivVV(q, r, u, v []uint) {
+	// See Knuth, "The Art of Computer Programming", Vol. 2, Section 4.3.1,
+	// Algorithm D, and "Hacker's Delight", Ch. 9-2: Multiword Division
+	n, m := len(v), len(u)
+	if n == 0 || v[n-1] == 0 {
+		panic(ErrDivideByZero)
+	}
+	if m < n {
+		// The quotient is zero, and the remainder is u.
+		copy(r, u)
+		for i := m; i < n; i++ {
+			r[i] = 0
+		}
+		return
+	}
+	if n == 1 {
+		r[0] = DivVW(q[:m], u, v[0])
+		return
+	}
+
+	// Normalize the divisor so that its most significant bit is set,
+	// and shift the dividend left by the same amount into an extra word.
+	// This keeps each quotient estimate within two of the true digit.
+	buf := make([]uint, n+m+1+n+1)
+	vn, un, t := buf[:n], buf[n:n+m+1], buf[n+m+1:]
+	s := uint(LeadingZeros(v[n-1]))
+	ShlVU(vn, v, s)
+	un[m] = ShlVU(un[:m], u, s)
+	vtop, vnext := vn[n-1], vn[n-2]
+
+	for j := m - n; j >= 0; j-- {
+		// Estimate the quotient digit from the top two words
+		// of the current remainder and the top word of the divisor.
+		// The remainder never exceeds the divisor, so ujn <= vtop.
+		ujn := un[j+n]
+		qhat := ^uint(0)
+		var rhat, c uint
+		if ujn < vtop {
+			qhat, rhat = Div(ujn, un[j+n-1], vtop)
+		} else {
+			// rhat = (ujn || un[j+n-1]) - qhat*vtop = un[j+n-1] + vtop
+			rhat, c = Add(un[j+n-1], vtop, 0)
+		}
+		// Refine the estimate using the next word of the divisor,
+		// until rhat no longer fits in a word.
+		for c == 0 {
+			hi, lo := Mul(qhat, vnext)
+			if hi < rhat || hi == rhat && lo <= un[j+n-2] {
+				break
+			}
+			qhat--
+			rhat, c = Add(rhat, vtop, 0)
+		}
+
+		// Multiply and subtract qhat*vn from the current remainder.
+		t[n] = MulAddVWW(t[:n], vn, qhat, 0)
+		if SubVV(un[j:j+n+1], un[j:j+n+1], t) != 0 {
+			// The estimate was one too large; add the divisor back.
+			qhat--
+			un[j+n] += AddVV(un[j:j+n], un[j:j+n], vn)
+		}
+		q[j] = qhat
+	}
+
+	// Unnormalize the remainder.
+	ShrVU(r[:n], un[:n], s)
+}
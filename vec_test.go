@@ -0,0 +1,355 @@
+// MIT License
+
+// Copyright (c) 2018 Akhil Indurti
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package extprec
+
+import (
+	"math/big"
+	"math/bits"
+	"math/rand"
+	"testing"
+)
+
+// natToBig returns the value of the little-endian words x.
+func natToBig(x []uint) *big.Int {
+	w := make([]big.Word, len(x))
+	for i, xi := range x {
+		w[i] = big.Word(xi)
+	}
+	return new(big.Int).SetBits(w)
+}
+
+// bigToNat returns z modulo 2^(n*bits.UintSize) as n little-endian words.
+func bigToNat(z *big.Int, n int) []uint {
+	m := new(big.Int).Lsh(big.NewInt(1), uint(n*bits.UintSize))
+	w := new(big.Int).Mod(z, m).Bits()
+	x := make([]uint, n)
+	for i, wi := range w {
+		x[i] = uint(wi)
+	}
+	return x
+}
+
+// randNat returns n little-endian words, biased toward
+// the all-zeros and all-ones words that exercise carries.
+func randNat(r *rand.Rand, n int) []uint {
+	x := make([]uint, n)
+	for i := range x {
+		switch r.Intn(4) {
+		case 0:
+			x[i] = 0
+		case 1:
+			x[i] = ^uint(0)
+		default:
+			x[i] = uint(r.Uint64())
+		}
+	}
+	return x
+}
+
+// less returns 1 if x < y and 0 otherwise.
+func less(x, y *big.Int) uint {
+	if x.Cmp(y) < 0 {
+		return 1
+	}
+	return 0
+}
+
+func equalNat(x, y []uint) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	for i := range x {
+		if x[i] != y[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestAddSubVV(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for n := 0; n <= 8; n++ {
+		for i := 0; i < 100; i++ {
+			x, y := randNat(r, n), randNat(r, n)
+			bx, by := natToBig(x), natToBig(y)
+			sum := new(big.Int).Add(bx, by)
+			z := make([]uint, n)
+			c := AddVV(z, x, y)
+			if want := bigToNat(sum, n); !equalNat(z, want) || c != uint(sum.Bit(n*bits.UintSize)) {
+				t.Errorf("AddVV(%X, %X) == (%X, %d); want (%X, %d)", x, y, z, c, want, sum.Bit(n*bits.UintSize))
+			}
+			c = SubVV(z, x, y)
+			if want, b := bigToNat(new(big.Int).Sub(bx, by), n), less(bx, by); !equalNat(z, want) || c != b {
+				t.Errorf("SubVV(%X, %X) == (%X, %d); want (%X, %d)", x, y, z, c, want, b)
+			}
+			// In-place: z aliases x.
+			z = append([]uint(nil), x...)
+			AddVV(z, z, y)
+			if want := bigToNat(sum, n); !equalNat(z, want) {
+				t.Errorf("AddVV(x, x, %X) set x to %X; want %X", y, z, want)
+			}
+		}
+	}
+}
+
+func TestAddSubVW(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for n := 0; n <= 8; n++ {
+		for i := 0; i < 100; i++ {
+			x, y := randNat(r, n), randNat(r, 1)[0]
+			bx, by := natToBig(x), natToBig([]uint{y})
+			sum := new(big.Int).Add(bx, by)
+			z := make([]uint, n)
+			c := AddVW(z, x, y)
+			wantC := uint(sum.Bit(n * bits.UintSize))
+			if n == 0 {
+				wantC = y
+			}
+			if want := bigToNat(sum, n); !equalNat(z, want) || c != wantC {
+				t.Errorf("AddVW(%X, %X) == (%X, %d); want (%X, %d)", x, y, z, c, want, wantC)
+			}
+			c = SubVW(z, x, y)
+			wantB := less(bx, by)
+			if n == 0 {
+				wantB = y
+			}
+			if want := bigToNat(new(big.Int).Sub(bx, by), n); !equalNat(z, want) || c != wantB {
+				t.Errorf("SubVW(%X, %X) == (%X, %d); want (%X, %d)", x, y, z, c, want, wantB)
+			}
+		}
+	}
+}
+
+func TestMulAddVWW(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for n := 0; n <= 8; n++ {
+		for i := 0; i < 100; i++ {
+			x, yr := randNat(r, n), randNat(r, 2)
+			y, c := yr[0], yr[1]
+			want := new(big.Int).Mul(natToBig(x), natToBig([]uint{y}))
+			want.Add(want, natToBig([]uint{c}))
+			wantZ, wantC := bigToNat(want, n), bigToNat(new(big.Int).Rsh(want, uint(n*bits.UintSize)), 1)[0]
+
+			z := make([]uint, n)
+			if c := MulAddVWW(z, x, y, c); !equalNat(z, wantZ) || c != wantC {
+				t.Errorf("MulAddVWW(%X, %X, %X) == (%X, %X); want (%X, %X)", x, y, yr[1], z, c, wantZ, wantC)
+			}
+
+			z = randNat(r, n)
+			want = new(big.Int).Mul(natToBig(x), natToBig([]uint{y}))
+			want.Add(want, natToBig(z))
+			wantZ, wantC = bigToNat(want, n), bigToNat(new(big.Int).Rsh(want, uint(n*bits.UintSize)), 1)[0]
+			z0 := append([]uint(nil), z...)
+			if c := AddMulVVW(z, x, y); !equalNat(z, wantZ) || c != wantC {
+				t.Errorf("AddMulVVW(%X, %X, %X) == (%X, %X); want (%X, %X)", z0, x, y, z, c, wantZ, wantC)
+			}
+		}
+	}
+}
+
+func TestMulAddVWW32(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	for n := 0; n <= 8; n++ {
+		for i := 0; i < 100; i++ {
+			x, y, cin := make([]uint32, n), r.Uint32(), r.Uint32()
+			z := make([]uint32, n)
+			for j := range x {
+				x[j], z[j] = r.Uint32(), r.Uint32()
+			}
+			// Compute the expected values one word at a time in 64 bits.
+			wantZ, wantC := make([]uint32, n), uint64(cin)
+			for j := range x {
+				p := uint64(x[j])*uint64(y) + wantC
+				wantZ[j], wantC = uint32(p), p>>32
+			}
+			got := make([]uint32, n)
+			if c := MulAddVWW32(got, x, y, cin); !equal32(got, wantZ) || c != uint32(wantC) {
+				t.Errorf("MulAddVWW32(%X, %X, %X) == (%X, %X); want (%X, %X)", x, y, cin, got, c, wantZ, wantC)
+			}
+			wantC = 0
+			for j := range x {
+				p := uint64(x[j])*uint64(y) + uint64(z[j]) + wantC
+				wantZ[j], wantC = uint32(p), p>>32
+			}
+			z0 := append([]uint32(nil), z...)
+			if c := AddMulVVW32(z, x, y); !equal32(z, wantZ) || c != uint32(wantC) {
+				t.Errorf("AddMulVVW32(%X, %X, %X) == (%X, %X); want (%X, %X)", z0, x, y, z, c, wantZ, wantC)
+			}
+		}
+	}
+}
+
+func TestMulAddVWW64(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	for n := 0; n <= 8; n++ {
+		for i := 0; i < 100; i++ {
+			x, y, cin := make([]uint64, n), r.Uint64(), r.Uint64()
+			z := make([]uint64, n)
+			for j := range x {
+				x[j], z[j] = r.Uint64(), r.Uint64()
+			}
+			// Compute the expected values one word at a time with the 128-bit type.
+			wantZ, wantC := make([]uint64, n), cin
+			for j := range x {
+				hi, lo := Mul64(x[j], y)
+				p := Uint128{hi, lo}.Add(Uint128{0, wantC})
+				wantZ[j], wantC = p.Lo, p.Hi
+			}
+			got := make([]uint64, n)
+			if c := MulAddVWW64(got, x, y, cin); !equal64(got, wantZ) || c != wantC {
+				t.Errorf("MulAddVWW64(%X, %X, %X) == (%X, %X); want (%X, %X)", x, y, cin, got, c, wantZ, wantC)
+			}
+			wantC = 0
+			for j := range x {
+				hi, lo := Mul64(x[j], y)
+				p := Uint128{hi, lo}.Add(Uint128{0, z[j]}).Add(Uint128{0, wantC})
+				wantZ[j], wantC = p.Lo, p.Hi
+			}
+			z0 := append([]uint64(nil), z...)
+			if c := AddMulVVW64(z, x, y); !equal64(z, wantZ) || c != wantC {
+				t.Errorf("AddMulVVW64(%X, %X, %X) == (%X, %X); want (%X, %X)", z0, x, y, z, c, wantZ, wantC)
+			}
+		}
+	}
+}
+
+func equal32(x, y []uint32) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	for i := range x {
+		if x[i] != y[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equal64(x, y []uint64) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	for i := range x {
+		if x[i] != y[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestShVU(t *testing.T) {
+	r := rand.New(rand.NewSource(6))
+	for n := 0; n <= 8; n++ {
+		for s := uint(0); s < bits.UintSize; s++ {
+			x := randNat(r, n)
+			bx := natToBig(x)
+
+			shl := new(big.Int).Lsh(bx, s)
+			wantZ, wantC := bigToNat(shl, n), bigToNat(new(big.Int).Rsh(shl, uint(n*bits.UintSize)), 1)[0]
+			z := make([]uint, n)
+			if c := ShlVU(z, x, s); !equalNat(z, wantZ) || c != wantC {
+				t.Errorf("ShlVU(%X, %d) == (%X, %X); want (%X, %X)", x, s, z, c, wantZ, wantC)
+			}
+			z = append(z[:0], x...)
+			if c := ShlVU(z, z, s); !equalNat(z, wantZ) || c != wantC {
+				t.Errorf("ShlVU(x, x, %d) with x = %X set x to %X and returned %X; want %X and %X", s, x, z, c, wantZ, wantC)
+			}
+
+			// Shifting the value up one word first keeps the
+			// bits shifted out of the bottom in the result.
+			shr := new(big.Int).Rsh(new(big.Int).Lsh(bx, bits.UintSize), s)
+			wantZ, wantC = bigToNat(new(big.Int).Rsh(shr, bits.UintSize), n), bigToNat(shr, 1)[0]
+			z = make([]uint, n)
+			if c := ShrVU(z, x, s); !equalNat(z, wantZ) || c != wantC {
+				t.Errorf("ShrVU(%X, %d) == (%X, %X); want (%X, %X)", x, s, z, c, wantZ, wantC)
+			}
+			z = append(z[:0], x...)
+			if c := ShrVU(z, z, s); !equalNat(z, wantZ) || c != wantC {
+				t.Errorf("ShrVU(x, x, %d) with x = %X set x to %X and returned %X; want %X and %X", s, x, z, c, wantZ, wantC)
+			}
+		}
+	}
+}
+
+func TestDivVW(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	for n := 0; n <= 8; n++ {
+		for i := 0; i < 100; i++ {
+			x, y := randNat(r, n), randNat(r, 1)[0]
+			if y == 0 {
+				y = 1
+			}
+			bx, by := natToBig(x), natToBig([]uint{y})
+			bq, br := new(big.Int).QuoRem(bx, by, new(big.Int))
+			z := make([]uint, n)
+			if rem := DivVW(z, x, y); !equalNat(z, bigToNat(bq, n)) || rem != bigToNat(br, 1)[0] {
+				t.Errorf("DivVW(%X, %X) == (%X, %X); want (%X, %X)", x, y, z, rem, bigToNat(bq, n), bigToNat(br, 1)[0])
+			}
+
+			// Prepend a leading word below y.
+			xn := uint(r.Uint64()) % y
+			bx.Add(bx, new(big.Int).Lsh(natToBig([]uint{xn}), uint(n*bits.UintSize)))
+			bq, br = bq.QuoRem(bx, by, br)
+			if rem := DivWVW(z, xn, x, y); !equalNat(z, bigToNat(bq, n)) || rem != bigToNat(br, 1)[0] {
+				t.Errorf("DivWVW(%X, %X, %X) == (%X, %X); want (%X, %X)", xn, x, y, z, rem, bigToNat(bq, n), bigToNat(br, 1)[0])
+			}
+		}
+	}
+	if err := divPanic(func() { DivVW(make([]uint, 1), []uint{1}, 0) }); err != ErrDivideByZero {
+		t.Errorf("DivVW(x, 0) panicked with %v; want %v", err, ErrDivideByZero)
+	}
+	if err := divPanic(func() { DivWVW(nil, 5, nil, 0) }); err != ErrDivideByZero {
+		t.Errorf("DivWVW(5, nil, 0) panicked with %v; want %v", err, ErrDivideByZero)
+	}
+	if err := divPanic(func() { DivWVW(nil, 5, nil, 5) }); err != ErrQuotientOverflow {
+		t.Errorf("DivWVW(5, nil, 5) panicked with %v; want %v", err, ErrQuotientOverflow)
+	}
+}
+
+func TestDivVV(t *testing.T) {
+	r := rand.New(rand.NewSource(8))
+	for m := 0; m <= 8; m++ {
+		for n := 1; n <= 6; n++ {
+			for i := 0; i < 200; i++ {
+				u, v := randNat(r, m), randNat(r, n)
+				if v[n-1] == 0 {
+					v[n-1] = 1 << uint(r.Intn(bits.UintSize))
+				}
+				bu, bv := natToBig(u), natToBig(v)
+				bq, br := new(big.Int).QuoRem(bu, bv, new(big.Int))
+				qn := m - n + 1
+				if qn < 0 {
+					qn = 0
+				}
+				q, rem := make([]uint, qn), make([]uint, n)
+				DivVV(q, rem, u, v)
+				if !equalNat(q, bigToNat(bq, qn)) || !equalNat(rem, bigToNat(br, n)) {
+					t.Errorf("DivVV(%X, %X) == (%X, %X); want (%X, %X)", u, v, q, rem, bigToNat(bq, qn), bigToNat(br, n))
+				}
+			}
+		}
+	}
+	if err := divPanic(func() { DivVV(nil, nil, []uint{1}, []uint{1, 0}) }); err != ErrDivideByZero {
+		t.Errorf("DivVV(x, [1 0]) panicked with %v; want %v", err, ErrDivideByZero)
+	}
+}